@@ -0,0 +1,198 @@
+package askpass
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	socketEnv = "FROGGIT_ASKPASS_SOCKET"
+	tokenEnv  = "FROGGIT_ASKPASS_TOKEN"
+)
+
+type helperRequest struct {
+	Token  string `json:"token"`
+	Prompt string `json:"prompt"`
+}
+
+type helperResponse struct {
+	OK    bool   `json:"ok"`
+	Value string `json:"value"`
+}
+
+// Request is a single credential prompt waiting for an answer from the TUI.
+type Request struct {
+	Prompt string
+	Secret bool
+	reply  chan helperResponse
+}
+
+// Answer sends the value typed by the user back to the waiting helper.
+func (r *Request) Answer(value string) {
+	r.reply <- helperResponse{OK: true, Value: value}
+}
+
+// Cancel makes the waiting helper exit with an error so git aborts the operation.
+func (r *Request) Cancel() {
+	r.reply <- helperResponse{OK: false}
+}
+
+// Server listens on a private local socket for prompts relayed by the
+// froggit binary when git or ssh run it as GIT_ASKPASS/SSH_ASKPASS.
+type Server struct {
+	dir      string
+	token    string
+	listener net.Listener
+	requests chan *Request
+}
+
+// Listen creates the socket in a fresh private temp directory and starts accepting helpers.
+func Listen() (*Server, error) {
+	dir, err := os.MkdirTemp("", "froggit-askpass-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create askpass directory: %w", err)
+	}
+	if err := os.Chmod(dir, 0o700); err != nil {
+		os.RemoveAll(dir)
+		return nil, fmt.Errorf("failed to secure askpass directory: %w", err)
+	}
+
+	listener, err := net.Listen("unix", filepath.Join(dir, "askpass.sock"))
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, fmt.Errorf("failed to listen for askpass helpers: %w", err)
+	}
+
+	token := make([]byte, 16)
+	if _, err := rand.Read(token); err != nil {
+		listener.Close()
+		os.RemoveAll(dir)
+		return nil, fmt.Errorf("failed to generate askpass token: %w", err)
+	}
+
+	s := &Server{
+		dir:      dir,
+		token:    hex.EncodeToString(token),
+		listener: listener,
+		requests: make(chan *Request),
+	}
+	go s.serve()
+	return s, nil
+}
+
+func (s *Server) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *Server) handle(conn net.Conn) {
+	defer conn.Close()
+
+	var req helperRequest
+	if err := json.NewDecoder(conn).Decode(&req); err != nil || req.Token != s.token {
+		return
+	}
+
+	r := &Request{
+		Prompt: strings.TrimSpace(req.Prompt),
+		Secret: IsSecretPrompt(req.Prompt),
+		reply:  make(chan helperResponse, 1),
+	}
+	s.requests <- r
+	json.NewEncoder(conn).Encode(<-r.reply)
+}
+
+// Requests delivers prompts one at a time; the next one is only read after the
+// TUI has answered or cancelled the current one.
+func (s *Server) Requests() <-chan *Request {
+	return s.requests
+}
+
+// Env returns the environment that makes git and ssh call exe back as their askpass helper.
+func (s *Server) Env(exe string) []string {
+	env := []string{
+		"GIT_ASKPASS=" + exe,
+		"SSH_ASKPASS=" + exe,
+		"SSH_ASKPASS_REQUIRE=force",
+		socketEnv + "=" + s.listener.Addr().String(),
+		tokenEnv + "=" + s.token,
+	}
+	// Older OpenSSH releases ignore SSH_ASKPASS_REQUIRE and only use the
+	// helper when DISPLAY is set.
+	if os.Getenv("DISPLAY") == "" {
+		env = append(env, "DISPLAY=froggit:0")
+	}
+	return env
+}
+
+// Close stops accepting helpers and removes the socket directory.
+func (s *Server) Close() error {
+	err := s.listener.Close()
+	os.RemoveAll(s.dir)
+	return err
+}
+
+// FallbackEnv is used when the askpass server could not be started: ssh is put
+// in batch mode so it fails instead of prompting on the hidden terminal.
+func FallbackEnv() []string {
+	if os.Getenv("GIT_SSH_COMMAND") != "" {
+		return nil
+	}
+	return []string{"GIT_SSH_COMMAND=ssh -o BatchMode=yes"}
+}
+
+// IsSecretPrompt reports whether the answer to prompt should be masked.
+// Usernames and host key confirmations are shown in clear text.
+func IsSecretPrompt(prompt string) bool {
+	p := strings.ToLower(prompt)
+	for _, word := range []string{"password", "passphrase", "token", "pin", "secret"} {
+		if strings.Contains(p, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsHelper reports whether this process was started by git or ssh as an askpass helper.
+func IsHelper() bool {
+	return os.Getenv(socketEnv) != "" && os.Getenv(tokenEnv) != ""
+}
+
+// RunHelper forwards the prompt in args to the running TUI, prints the answer
+// on stdout as git and ssh expect, and returns the process exit code.
+func RunHelper(args []string) int {
+	conn, err := net.Dial("unix", os.Getenv(socketEnv))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "froggit askpass: %v\n", err)
+		return 1
+	}
+	defer conn.Close()
+
+	req := helperRequest{
+		Token:  os.Getenv(tokenEnv),
+		Prompt: strings.Join(args, " "),
+	}
+	if err := json.NewEncoder(conn).Encode(req); err != nil {
+		fmt.Fprintf(os.Stderr, "froggit askpass: %v\n", err)
+		return 1
+	}
+
+	var resp helperResponse
+	if err := json.NewDecoder(bufio.NewReader(conn)).Decode(&resp); err != nil || !resp.OK {
+		return 1
+	}
+	fmt.Fprintln(os.Stdout, resp.Value)
+	return 0
+}
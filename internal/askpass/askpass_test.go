@@ -0,0 +1,59 @@
+package askpass
+
+import (
+	"encoding/json"
+	"net"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestIsSecretPrompt(t *testing.T) {
+	cases := map[string]bool{
+		"Password for 'https://user@github.com': ":                              true,
+		"Enter passphrase for key '/home/u/.ssh/id_ed25519': ":                  true,
+		"Username for 'https://github.com': ":                                   false,
+		"Are you sure you want to continue connecting (yes/no/[fingerprint])? ": false,
+	}
+	for prompt, want := range cases {
+		if got := IsSecretPrompt(prompt); got != want {
+			t.Fatalf("IsSecretPrompt(%q) = %v; want %v", prompt, got, want)
+		}
+	}
+}
+
+func TestServerRelaysPrompt(t *testing.T) {
+	s, err := Listen()
+	if err != nil {
+		t.Skipf("unix sockets unavailable: %v", err)
+	}
+	defer s.Close()
+
+	go func() {
+		r := <-s.Requests()
+		if !r.Secret || !strings.HasPrefix(r.Prompt, "Password") {
+			r.Cancel()
+			return
+		}
+		r.Answer("hunter2")
+	}()
+
+	conn, err := net.Dial("unix", s.listener.Addr().String())
+	if err != nil {
+		t.Fatalf("dial failed: %v", err)
+	}
+	defer conn.Close()
+
+	json.NewEncoder(conn).Encode(helperRequest{Token: s.token, Prompt: "Password for 'https://example.com': "})
+	var resp helperResponse
+	if err := json.NewDecoder(conn).Decode(&resp); err != nil {
+		t.Fatalf("failed to read response: %v", err)
+	}
+	if !resp.OK || resp.Value != "hunter2" {
+		t.Fatalf("unexpected response %+v", resp)
+	}
+
+	if _, err := os.Stat(s.dir); err != nil {
+		t.Fatalf("socket directory missing: %v", err)
+	}
+}
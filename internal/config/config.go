@@ -14,16 +14,44 @@ type Config struct {
 type UiConfig struct {
 	Branding bool   `yaml:"branding"`
 	Position string `yaml:"position"`
+	// Layout is "single" (one view at a time) or "dashboard" (files,
+	// branches, stash and commits side by side with a details panel).
+	Layout string `yaml:"layout"`
+	// Editor is the command files are opened with, with {file} and {line}
+	// placeholders, e.g. "code -g {file}:{line}". Empty uses $VISUAL/$EDITOR.
+	Editor string `yaml:"editor"`
 }
 
 type GitConfig struct {
 	AutoFetch     bool   `yaml:"autofetch"`
 	DefaultBranch string `yaml:"defaultbranch"`
+	// PullStrategy is one of "ff-only", "merge", "rebase" or "autostash".
+	// Empty leaves the choice to git's pull.rebase/pull.ff settings.
+	PullStrategy string `yaml:"pullstrategy"`
+	// DiffPager is a command diffs are piped through for display, such as
+	// "delta", or "git" to use git's pager.diff/core.pager settings. Empty
+	// keeps froggit's built-in diff rendering.
+	DiffPager string `yaml:"diffpager"`
+	// Watch is how changes made outside froggit are noticed: "auto" watches
+	// the files and falls back to polling, "poll" always polls and "off"
+	// only refreshes on demand.
+	Watch string `yaml:"watch"`
+	// LargeRepo is "auto" (the default), "on" or "off". For large
+	// repositories the status is read without untracked files, which are
+	// listed on demand, and with git's untracked cache; "auto" turns this on
+	// from 20000 tracked files.
+	LargeRepo string `yaml:"largerepo"`
+	// FSMonitor also uses git's builtin fsmonitor for large repositories.
+	// It is off by default because git starts a daemon for it that keeps
+	// running after froggit exits.
+	FSMonitor bool `yaml:"fsmonitor"`
 }
 
 func LoadConfig(filename string) (Config, error) {
-	exeDir := getExecutableDir()
-	configPath := filepath.Join(exeDir, filename)
+	configPath := filename
+	if !filepath.IsAbs(filename) {
+		configPath = filepath.Join(getExecutableDir(), filename)
+	}
 
 	f, err := os.ReadFile(configPath)
 	if err != nil {
@@ -39,9 +67,27 @@ func LoadConfig(filename string) (Config, error) {
 	if cfg.Ui.Position == "" {
 		cfg.Ui.Position = "left"
 	}
+	if cfg.Ui.Layout != "dashboard" {
+		cfg.Ui.Layout = "single"
+	}
 	if cfg.Git.DefaultBranch == "" {
 		cfg.Git.DefaultBranch = "main"
 	}
+	switch cfg.Git.Watch {
+	case "poll", "off":
+	default:
+		cfg.Git.Watch = "auto"
+	}
+	switch cfg.Git.LargeRepo {
+	case "on", "off":
+	default:
+		cfg.Git.LargeRepo = "auto"
+	}
+	switch cfg.Git.PullStrategy {
+	case "", "ff-only", "merge", "rebase", "autostash":
+	default:
+		cfg.Git.PullStrategy = ""
+	}
 
 	return cfg, nil
 }
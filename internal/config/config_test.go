@@ -7,7 +7,7 @@ import (
 
 func TestLoadConfig_DefaultsAndParsing(t *testing.T) {
 	// create temp file
-	content := "ui:\n  branding: true\n  position: \"\"\ngit:\n  autofetch: true\n"
+	content := "ui:\n  branding: true\n  position: \"\"\ngit:\n  autofetch: true\n  pullstrategy: octopus\n"
 	f, err := os.CreateTemp("", "cfg-*.yml")
 	if err != nil {
 		t.Fatalf("failed to create temp file: %v", err)
@@ -34,4 +34,19 @@ func TestLoadConfig_DefaultsAndParsing(t *testing.T) {
 	if cfg.Git.DefaultBranch != "main" {
 		t.Fatalf("expected DefaultBranch 'main', got %q", cfg.Git.DefaultBranch)
 	}
+	// unknown pull strategies fall back to git's own configuration
+	if cfg.Git.PullStrategy != "" {
+		t.Fatalf("expected empty PullStrategy, got %q", cfg.Git.PullStrategy)
+	}
+	// external changes are watched for unless configured otherwise
+	if cfg.Git.Watch != "auto" {
+		t.Fatalf("expected Git.Watch 'auto', got %q", cfg.Git.Watch)
+	}
+	if cfg.Git.LargeRepo != "auto" {
+		t.Fatalf("expected Git.LargeRepo 'auto', got %q", cfg.Git.LargeRepo)
+	}
+	// layout defaults to one view at a time
+	if cfg.Ui.Layout != "single" {
+		t.Fatalf("expected Ui.Layout 'single', got %q", cfg.Ui.Layout)
+	}
 }
@@ -0,0 +1,133 @@
+// Package editor builds the command that opens a file in the user's editor.
+package editor
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// fallback is used when neither $VISUAL nor $EDITOR is set.
+const fallback = "vi"
+
+// Command returns the command that opens file, at line when it is positive.
+//
+// template is a command line with {file} and {line} placeholders, e.g.
+// "code -g {file}:{line}"; the file is appended when it has no {file}. It is
+// split into arguments like a shell would, honouring quotes, and the
+// placeholders are replaced within each argument, so "{file}" may be quoted
+// or not. No shell runs it. An empty template uses $VISUAL or $EDITOR,
+// passing the line in the form well-known editors understand.
+func Command(template, file string, line int) *exec.Cmd {
+	if template == "" {
+		return command(editorArgs(splitArgs(envEditor()), file, line))
+	}
+
+	if line <= 0 {
+		line = 1
+	}
+	args := splitArgs(template)
+	hasFile := false
+	for i, arg := range args {
+		hasFile = hasFile || strings.Contains(arg, "{file}")
+		arg = strings.ReplaceAll(arg, "{line}", strconv.Itoa(line))
+		args[i] = strings.ReplaceAll(arg, "{file}", file)
+	}
+	if !hasFile {
+		args = append(args, file)
+	}
+	return command(args)
+}
+
+// command runs args, falling back to the default editor when they are empty.
+func command(args []string) *exec.Cmd {
+	if len(args) == 0 {
+		args = []string{fallback}
+	}
+	return exec.Command(args[0], args[1:]...)
+}
+
+// envEditor returns the editor the environment asks for.
+func envEditor() string {
+	for _, name := range []string{"VISUAL", "EDITOR"} {
+		if editor := strings.TrimSpace(os.Getenv(name)); editor != "" {
+			return editor
+		}
+	}
+	return fallback
+}
+
+// editorArgs adds file and line to the arguments of an editor, using the
+// syntax of the editor when it is a known one.
+func editorArgs(editor []string, file string, line int) []string {
+	if len(editor) == 0 {
+		editor = []string{fallback}
+	}
+	if line <= 0 {
+		return append(editor, file)
+	}
+
+	at := strconv.Itoa(line)
+	switch filepath.Base(editor[0]) {
+	case "vi", "vim", "nvim", "nano", "emacs", "emacsclient", "micro", "kak", "joe", "mg":
+		return append(editor, "+"+at, file)
+	case "code", "code-insiders", "codium", "cursor":
+		return append(editor, "-g", file+":"+at)
+	case "subl", "hx", "helix", "zed":
+		return append(editor, file+":"+at)
+	}
+	return append(editor, file)
+}
+
+// splitArgs splits a command line into its arguments the way a shell does
+// for words: on blanks outside quotes, with single quotes taken literally
+// and backslashes escaping the next character elsewhere.
+func splitArgs(s string) []string {
+	var (
+		args    []string
+		current strings.Builder
+		inWord  bool
+		quote   rune
+		escaped bool
+	)
+	for _, r := range s {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case quote == '\'':
+			if r == '\'' {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '\\':
+			escaped = true
+			inWord = true
+		case quote == '"':
+			if r == '"' {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inWord = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inWord {
+				args = append(args, current.String())
+				current.Reset()
+				inWord = false
+			}
+		default:
+			current.WriteRune(r)
+			inWord = true
+		}
+	}
+	if inWord {
+		args = append(args, current.String())
+	}
+	return args
+}
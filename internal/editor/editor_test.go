@@ -0,0 +1,39 @@
+package editor
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCommand(t *testing.T) {
+	cases := []struct {
+		template string
+		editor   string
+		line     int
+		want     string
+	}{
+		{"code -g {file}:{line}", "", 12, "code|-g|it's a.go:12"},
+		{`code -g "{file}:{line}"`, "", 12, "code|-g|it's a.go:12"},
+		{"vim '+{line}' '{file}'", "", 0, "vim|+1|it's a.go"},
+		{`"/opt/My Editor/bin/edit" --wait`, "", 3, "/opt/My Editor/bin/edit|--wait|it's a.go"},
+		{"subl", "", 0, "subl|it's a.go"},
+		{"", "nvim", 7, "nvim|+7|it's a.go"},
+		{"", "code --wait", 7, "code|--wait|-g|it's a.go:7"},
+		{"", "ed", 7, "ed|it's a.go"},
+	}
+
+	for _, c := range cases {
+		t.Setenv("VISUAL", c.editor)
+		cmd := Command(c.template, "it's a.go", c.line)
+		if got := strings.Join(cmd.Args, "|"); got != c.want {
+			t.Errorf("Command(%q, %q) runs %q; want %q", c.template, c.editor, got, c.want)
+		}
+	}
+}
+
+func TestSplitArgs(t *testing.T) {
+	got := strings.Join(splitArgs(`emacsclient -a '' "{file}" it\'s`), "|")
+	if want := "emacsclient|-a||{file}|it's"; got != want {
+		t.Errorf("splitArgs = %q; want %q", got, want)
+	}
+}
@@ -2,6 +2,9 @@ package git
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 )
 
@@ -44,6 +47,49 @@ func (g *GitClient) Reset(filename string) error {
 	return err
 }
 
+func AddPaths(paths []string) error {
+	return NewGitClient("").AddPaths(paths)
+}
+
+// AddPaths stages many paths with one git command, reading them from stdin
+// so the list can't outgrow the command line.
+func (g *GitClient) AddPaths(paths []string) error {
+	if _, err := g.runWithPathspecs(paths, "add"); err != nil {
+		return fmt.Errorf("failed to stage files: %w", err)
+	}
+	return nil
+}
+
+func ResetPaths(paths []string) error {
+	return NewGitClient("").ResetPaths(paths)
+}
+
+// ResetPaths unstages many paths with one git command. Without any commit
+// there is nothing to reset to, so they are removed from the index instead.
+func (g *GitClient) ResetPaths(paths []string) error {
+	args := []string{"reset", "-q", "HEAD"}
+	if _, err := g.runGitCommand("rev-parse", "HEAD"); err != nil {
+		args = []string{"rm", "-q", "-r", "--cached"}
+	}
+	if _, err := g.runWithPathspecs(paths, args...); err != nil {
+		return fmt.Errorf("failed to unstage files: %w", err)
+	}
+	return nil
+}
+
+// runWithPathspecs runs a git command on the paths, passed NUL-separated on
+// stdin.
+func (g *GitClient) runWithPathspecs(paths []string, args ...string) ([]byte, error) {
+	args = append(args, "--pathspec-from-file=-", "--pathspec-file-nul")
+	cmd := g.newCommand(args...)
+	cmd.Stdin = strings.NewReader(strings.Join(paths, "\x00"))
+	output, err := cmd.CombinedOutput()
+	if err != nil && len(output) > 0 {
+		return output, fmt.Errorf("%w: %s", err, strings.TrimSpace(string(output)))
+	}
+	return output, err
+}
+
 func Commit(message string) error {
 	return NewGitClient("").Commit(message)
 }
@@ -74,21 +120,16 @@ func (g *GitClient) Rebase(branch string) error {
 	return err
 }
 
-func SaveStash(message string) error {
-	return NewGitClient("").SaveStash(message)
-}
-
-func (g *GitClient) SaveStash(message string) error {
-	_, err := g.runGitCommandCombinedOutput("stash", "push", "-m", message)
-	return err
-}
-
-func StashPop() error {
-	return NewGitClient("").StashPop()
+func StashPop(ref string) error {
+	return NewGitClient("").StashPop(ref)
 }
 
-func (g *GitClient) StashPop() error {
-	_, err := g.runGitCommandCombinedOutput("stash", "pop")
+// StashPop applies the stash ref and drops it once it applied cleanly.
+func (g *GitClient) StashPop(ref string) error {
+	output, err := g.runGitCommandCombinedOutput("stash", "pop", ref)
+	if err != nil && len(output) > 0 {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(output)))
+	}
 	return err
 }
 
@@ -123,9 +164,14 @@ func (g *GitClient) HasRemoteChangesWithFetch(branch string, doFetch bool) (bool
 		}
 	}
 
-	output, err := g.runGitCommand("rev-list", "--count", fmt.Sprintf("HEAD..origin/%s", branch))
+	// Prefer the configured upstream; fall back to origin/<branch> for
+	// branches that were never pushed with tracking.
+	output, err := g.runGitCommand("rev-list", "--count", "HEAD..@{u}")
 	if err != nil {
-		return false, err
+		output, err = g.runGitCommand("rev-list", "--count", fmt.Sprintf("HEAD..origin/%s", branch))
+		if err != nil {
+			return false, err
+		}
 	}
 
 	count := strings.TrimSpace(string(output))
@@ -144,8 +190,134 @@ func (g *GitClient) LogsGraph() (string, error) {
 	return string(output), nil
 }
 
+func RecentLog(n int) (string, error) {
+	return NewGitClient("").RecentLog(n)
+}
+
+// RecentLog returns the log graph of all branches limited to the last n commits.
+func (g *GitClient) RecentLog(n int) (string, error) {
+	output, err := g.runGitCommand("log", "--graph", "--oneline", "--all", "-n", strconv.Itoa(n))
+	if err != nil {
+		return "", fmt.Errorf("failed to get logs: %w", err)
+	}
+	return string(output), nil
+}
+
+func BranchLog(ref string, n int) (string, error) {
+	return NewGitClient("").BranchLog(ref, n)
+}
+
+// BranchLog returns the last n commits of ref with their decorations.
+func (g *GitClient) BranchLog(ref string, n int) (string, error) {
+	output, err := g.runGitCommand("log", "--oneline", "--decorate", "-n", strconv.Itoa(n), ref, "--")
+	if err != nil {
+		return "", fmt.Errorf("failed to get log of %s: %w", ref, err)
+	}
+	return string(output), nil
+}
+
+func ShowCommit(hash string) (string, error) {
+	return NewGitClient("").ShowCommit(hash)
+}
+
+// ShowCommit returns the header, stat and patch of a commit.
+func (g *GitClient) ShowCommit(hash string) (string, error) {
+	output, err := g.runGitCommand("show", "--stat", "--patch", "--format=medium", hash)
+	if err != nil {
+		return "", fmt.Errorf("failed to show commit %s: %w", hash, err)
+	}
+	return string(output), nil
+}
+
+// LogLineHash returns the abbreviated commit hash of a "log --graph --oneline"
+// line, or "" for lines that only continue the graph.
+func LogLineHash(line string) string {
+	for _, field := range strings.Fields(line) {
+		if strings.Trim(field, "*|/\\_-.") == "" {
+			continue
+		}
+		if len(field) >= 4 && strings.Trim(field, "0123456789abcdef") == "" {
+			return field
+		}
+		return ""
+	}
+	return ""
+}
+
+// LogSearchKind selects how SearchLog matches commits.
+type LogSearchKind int
+
+const (
+	LogSearchPickaxe LogSearchKind = iota // -S: commits changing the number of occurrences of a string
+	LogSearchRegex                        // -G: commits whose diff matches a regex
+	LogSearchMessage                      // --grep: commit messages
+	LogSearchAuthor                       // --author
+)
+
+// LogSearchKinds lists the log search modes in the order they are offered.
+var LogSearchKinds = []LogSearchKind{LogSearchPickaxe, LogSearchRegex, LogSearchMessage, LogSearchAuthor}
+
+// String returns the git option behind the search kind.
+func (k LogSearchKind) String() string {
+	switch k {
+	case LogSearchRegex:
+		return "-G"
+	case LogSearchMessage:
+		return "--grep"
+	case LogSearchAuthor:
+		return "--author"
+	default:
+		return "-S"
+	}
+}
+
+// Description explains the search kind for the search dialog.
+func (k LogSearchKind) Description() string {
+	switch k {
+	case LogSearchRegex:
+		return "diff lines matching a regex"
+	case LogSearchMessage:
+		return "commit message"
+	case LogSearchAuthor:
+		return "author name or email"
+	default:
+		return "added or removed occurrences of a string"
+	}
+}
+
+// Arg returns the git log argument searching for query.
+func (k LogSearchKind) Arg(query string) string {
+	switch k {
+	case LogSearchMessage, LogSearchAuthor:
+		return k.String() + "=" + query
+	default:
+		return k.String() + query
+	}
+}
+
+func SearchLog(kind LogSearchKind, query string) (string, error) {
+	return NewGitClient("").SearchLog(kind, query)
+}
+
+// SearchLog returns the log graph limited to the commits matching query.
+func (g *GitClient) SearchLog(kind LogSearchKind, query string) (string, error) {
+	args := []string{"log", "--graph", "--oneline", "--all", kind.Arg(query)}
+	if kind == LogSearchMessage || kind == LogSearchAuthor {
+		args = append(args, "--regexp-ignore-case")
+	}
+	output, err := g.runGitCommand(args...)
+	if err != nil {
+		return "", fmt.Errorf("failed to search logs: %w", err)
+	}
+	return string(output), nil
+}
+
 func GetConflictFiles() ([]string, error) {
-	output, err := NewGitClient("").runGitCommand("diff", "--name-only", "--diff-filter=U")
+	return NewGitClient("").GetConflictFiles()
+}
+
+func (g *GitClient) GetConflictFiles() ([]string, error) {
+	output, err := g.runGitCommand("diff", "--name-only", "--diff-filter=U")
 	if err != nil {
 		return nil, err
 	}
@@ -169,6 +341,28 @@ func MergeAbort() error {
 	return err
 }
 
+func IsRebaseInProgress() bool {
+	return NewGitClient("").IsRebaseInProgress()
+}
+
+// IsRebaseInProgress reports whether a rebase stopped and is waiting for --continue or --abort.
+func (g *GitClient) IsRebaseInProgress() bool {
+	for _, dir := range []string{"rebase-merge", "rebase-apply"} {
+		output, err := g.runGitCommand("rev-parse", "--git-path", dir)
+		if err != nil {
+			continue
+		}
+		path := strings.TrimSpace(string(output))
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(g.RepoPath, path)
+		}
+		if _, err := os.Stat(path); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
 func RebaseContinue() error {
 	_, err := NewGitClient("").runGitCommandCombinedOutput("rebase", "--continue")
 	return err
@@ -229,17 +423,48 @@ func HasCommitsToush() (bool, error) {
 	return NewGitClient("").HasCommitsToush()
 }
 
-func GetFileDiff(filename string, staged bool) (string, error) {
-	return NewGitClient("").GetFileDiff(filename, staged)
+// DefaultDiffContext is how many lines of context git shows around changes.
+const DefaultDiffContext = 3
+
+// DiffOptions tune how file diffs are generated.
+type DiffOptions struct {
+	IgnoreWhitespace bool
+	Context          int // lines of context around changes; 0 uses DefaultDiffContext
+}
+
+// ContextLines returns the number of context lines the diff is generated with.
+func (o DiffOptions) ContextLines() int {
+	if o.Context <= 0 {
+		return DefaultDiffContext
+	}
+	return o.Context
+}
+
+// Args returns the git diff arguments for these options.
+func (o DiffOptions) Args() []string {
+	return append([]string{"diff"}, o.Flags()...)
+}
+
+// Flags returns the options as flags for any git command showing diffs.
+func (o DiffOptions) Flags() []string {
+	flags := []string{fmt.Sprintf("-U%d", o.ContextLines())}
+	if o.IgnoreWhitespace {
+		flags = append(flags, "--ignore-all-space")
+	}
+	return flags
+}
+
+func GetFileDiff(filename string, staged bool, opts DiffOptions) (string, error) {
+	return NewGitClient("").GetFileDiff(filename, staged, opts)
 }
 
-func (g *GitClient) GetFileDiff(filename string, staged bool) (string, error) {
-	var args []string
+func (g *GitClient) GetFileDiff(filename string, staged bool, opts DiffOptions) (string, error) {
+	// diff.external output can't be parsed, RenderFileDiff shows it instead
+	args := append(opts.Args(), "--no-ext-diff")
 	if staged {
-		args = []string{"diff", "--cached", "--", filename}
-	} else {
-		args = []string{"diff", "--", filename}
+		args = append(args, "--cached")
 	}
+	args = append(args, "--", filename)
 	output, err := g.runGitCommand(args...)
 	if err != nil {
 		return "", fmt.Errorf("failed to get diff for %s: %w", filename, err)
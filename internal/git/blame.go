@@ -0,0 +1,89 @@
+package git
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// BlameLine is a line of a file together with the commit that last changed
+// it.
+type BlameLine struct {
+	Hash         string
+	Author       string
+	Date         time.Time
+	Summary      string
+	Path         string // name of the file in that commit
+	OrigLine     int    // line number in that commit
+	Previous     string // parent commit the line can be blamed further back from, "" at the root
+	PreviousPath string
+	Text         string
+}
+
+// Uncommitted reports whether the line was changed in the worktree.
+func (l BlameLine) Uncommitted() bool {
+	return strings.Trim(l.Hash, "0") == ""
+}
+
+func Blame(path, rev string) ([]BlameLine, error) {
+	return NewGitClient("").Blame(path, rev)
+}
+
+// Blame annotates every line of path with the commit that last changed it,
+// in the worktree when rev is empty or as of commit rev otherwise.
+func (g *GitClient) Blame(path, rev string) ([]BlameLine, error) {
+	args := []string{"blame", "--line-porcelain"}
+	if rev != "" {
+		args = append(args, rev)
+	}
+	args = append(args, "--", path)
+	output, err := g.runGitCommand(args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to blame %s: %w", path, err)
+	}
+	return parseBlame(string(output)), nil
+}
+
+// parseBlame parses git blame --line-porcelain output, where every line of
+// the file comes after a header naming its commit and the commit details.
+func parseBlame(output string) []BlameLine {
+	var lines []BlameLine
+	var cur BlameLine
+	header := true
+	for _, row := range strings.Split(output, "\n") {
+		if text, ok := strings.CutPrefix(row, "\t"); ok {
+			cur.Text = text
+			lines = append(lines, cur)
+			cur = BlameLine{}
+			header = true
+			continue
+		}
+		if header {
+			fields := strings.Fields(row)
+			if len(fields) >= 3 {
+				cur.Hash = fields[0]
+				cur.OrigLine, _ = strconv.Atoi(fields[1])
+			}
+			header = false
+			continue
+		}
+
+		key, value, _ := strings.Cut(row, " ")
+		switch key {
+		case "author":
+			cur.Author = value
+		case "author-time":
+			if ts, err := strconv.ParseInt(value, 10, 64); err == nil {
+				cur.Date = time.Unix(ts, 0)
+			}
+		case "summary":
+			cur.Summary = value
+		case "filename":
+			cur.Path = value
+		case "previous":
+			cur.Previous, cur.PreviousPath, _ = strings.Cut(value, " ")
+		}
+	}
+	return lines
+}
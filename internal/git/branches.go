@@ -1,7 +1,10 @@
 package git
 
 import (
+	"fmt"
+	"strconv"
 	"strings"
+	"time"
 )
 
 func GetBranches() ([]string, string) {
@@ -58,3 +61,226 @@ func (g *GitClient) Checkout(branch string) error {
 	return err
 }
 
+// BranchInfo describes a local branch together with its upstream tracking state.
+type BranchInfo struct {
+	Name       string
+	Current    bool
+	Upstream   string // e.g. "origin/main"; empty when not tracking
+	Gone       bool   // upstream is configured but no longer exists on the remote
+	Ahead      int
+	Behind     int
+	LastCommit time.Time
+	Author     string
+}
+
+const branchInfoFormat = "%(HEAD)%00%(refname:short)%00%(upstream:short)%00%(upstream:track,nobracket)%00%(committerdate:unix)%00%(authorname)"
+
+func GetBranchInfos() ([]BranchInfo, error) {
+	return NewGitClient("").GetBranchInfos()
+}
+
+// GetBranchInfos lists local branches with upstream, ahead/behind counts and
+// the date and author of their last commit.
+func (g *GitClient) GetBranchInfos() ([]BranchInfo, error) {
+	output, err := g.runGitCommand("for-each-ref", "--format="+branchInfoFormat, "refs/heads")
+	if err != nil {
+		return nil, err
+	}
+	return parseBranchInfos(string(output)), nil
+}
+
+func parseBranchInfos(output string) []BranchInfo {
+	var infos []BranchInfo
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		fields := strings.Split(line, "\x00")
+		if len(fields) < 6 || fields[1] == "" {
+			continue
+		}
+		info := BranchInfo{
+			Name:     fields[1],
+			Current:  fields[0] == "*",
+			Upstream: fields[2],
+			Author:   fields[5],
+		}
+		info.Ahead, info.Behind, info.Gone = parseTrack(fields[3])
+		if ts, err := strconv.ParseInt(fields[4], 10, 64); err == nil {
+			info.LastCommit = time.Unix(ts, 0)
+		}
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+// parseTrack parses %(upstream:track,nobracket), e.g. "ahead 2, behind 1" or "gone".
+func parseTrack(track string) (ahead, behind int, gone bool) {
+	if track == "gone" {
+		return 0, 0, true
+	}
+	for _, part := range strings.Split(track, ",") {
+		fields := strings.Fields(part)
+		if len(fields) != 2 {
+			continue
+		}
+		n, err := strconv.Atoi(fields[1])
+		if err != nil {
+			continue
+		}
+		switch fields[0] {
+		case "ahead":
+			ahead = n
+		case "behind":
+			behind = n
+		}
+	}
+	return ahead, behind, false
+}
+
+func SetUpstream(branch, upstream string) error {
+	return NewGitClient("").SetUpstream(branch, upstream)
+}
+
+// SetUpstream makes branch track upstream, e.g. "origin/main".
+func (g *GitClient) SetUpstream(branch, upstream string) error {
+	output, err := g.runGitCommandCombinedOutput("branch", "--set-upstream-to="+upstream, branch)
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+func UnsetUpstream(branch string) error {
+	return NewGitClient("").UnsetUpstream(branch)
+}
+
+func (g *GitClient) UnsetUpstream(branch string) error {
+	output, err := g.runGitCommandCombinedOutput("branch", "--unset-upstream", branch)
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+func RenameBranch(oldName, newName string) error {
+	return NewGitClient("").RenameBranch(oldName, newName)
+}
+
+func (g *GitClient) RenameBranch(oldName, newName string) error {
+	output, err := g.runGitCommandCombinedOutput("branch", "-m", oldName, newName)
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+func RenameRemoteBranch(remote, oldRemoteName, newName string) error {
+	return NewGitClient("").RenameRemoteBranch(remote, oldRemoteName, newName)
+}
+
+// RenameRemoteBranch publishes the (already renamed) local branch newName on
+// remote, makes it the upstream and deletes the old branch there.
+func (g *GitClient) RenameRemoteBranch(remote, oldRemoteName, newName string) error {
+	if err := beginOperation(); err != nil {
+		return err
+	}
+	defer endOperation()
+
+	output, err := g.runGitCommandCombinedOutput("push", "--set-upstream", remote, newName+":refs/heads/"+newName)
+	if err != nil {
+		return fmt.Errorf("failed to push %s: %v - %s", newName, err, strings.TrimSpace(string(output)))
+	}
+	if oldRemoteName == newName {
+		return nil
+	}
+	output, err = g.runGitCommandCombinedOutput("push", remote, "--delete", oldRemoteName)
+	if err != nil {
+		return fmt.Errorf("pushed %s but failed to delete %s/%s: %v - %s", newName, remote, oldRemoteName, err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+func ForceDeleteBranch(name string) error {
+	return NewGitClient("").ForceDeleteBranch(name)
+}
+
+// ForceDeleteBranch deletes a branch even if it has commits not merged anywhere.
+func (g *GitClient) ForceDeleteBranch(name string) error {
+	output, err := g.runGitCommandCombinedOutput("branch", "-D", name)
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+func IsBranchMerged(name, into string) bool {
+	return NewGitClient("").IsBranchMerged(name, into)
+}
+
+// IsBranchMerged reports whether every commit of name is reachable from into.
+func (g *GitClient) IsBranchMerged(name, into string) bool {
+	_, err := g.runGitCommand("merge-base", "--is-ancestor", "refs/heads/"+name, into)
+	return err == nil
+}
+
+func ResolveDefaultBranch(configured string) string {
+	return NewGitClient("").ResolveDefaultBranch(configured)
+}
+
+// ResolveDefaultBranch returns configured when such a local branch exists,
+// otherwise the branch origin/HEAD points to, falling back to configured.
+func (g *GitClient) ResolveDefaultBranch(configured string) string {
+	if _, err := g.runGitCommand("rev-parse", "--verify", "--quiet", "refs/heads/"+configured); err == nil {
+		return configured
+	}
+	output, err := g.runGitCommand("symbolic-ref", "--short", "refs/remotes/origin/HEAD")
+	if err == nil {
+		if _, name, ok := strings.Cut(strings.TrimSpace(string(output)), "/"); ok {
+			return name
+		}
+	}
+	return configured
+}
+
+// CleanupCandidate is a local branch that looks safe to delete.
+type CleanupCandidate struct {
+	Name     string
+	Merged   bool // fully merged into the default branch
+	Gone     bool // upstream was deleted on the remote
+	Selected bool
+}
+
+func FindCleanupCandidates(base string) ([]CleanupCandidate, error) {
+	return NewGitClient("").FindCleanupCandidates(base)
+}
+
+// FindCleanupCandidates lists branches merged into base or whose upstream is
+// gone, excluding base itself and the current branch. Merged branches start
+// selected; unmerged ones have to be picked by hand.
+func (g *GitClient) FindCleanupCandidates(base string) ([]CleanupCandidate, error) {
+	infos, err := g.GetBranchInfos()
+	if err != nil {
+		return nil, err
+	}
+
+	merged := make(map[string]bool)
+	if output, err := g.runGitCommand("for-each-ref", "--merged="+base, "--format=%(refname:short)", "refs/heads"); err == nil {
+		for _, name := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+			merged[name] = true
+		}
+	}
+
+	var candidates []CleanupCandidate
+	for _, info := range infos {
+		if info.Current || info.Name == base {
+			continue
+		}
+		if merged[info.Name] || info.Gone {
+			candidates = append(candidates, CleanupCandidate{
+				Name:     info.Name,
+				Merged:   merged[info.Name],
+				Gone:     info.Gone,
+				Selected: merged[info.Name],
+			})
+		}
+	}
+	return candidates, nil
+}
@@ -1,12 +1,24 @@
 package git
 
 import (
+	"os"
 	"os/exec"
 	"strings"
 )
 
 type GitClient struct {
-	RepoPath string
+	RepoPath  string
+	indexFile string // index used instead of the repository's, "" for the default
+}
+
+// commandEnv holds extra environment variables passed to every git command,
+// e.g. the askpass helper configuration set up by main.
+var commandEnv []string
+
+// SetCommandEnv sets extra environment variables for all git commands.
+// It must be called before any command runs.
+func SetCommandEnv(env []string) {
+	commandEnv = env
 }
 
 func NewGitClient(repoPath string) *GitClient {
@@ -29,18 +41,26 @@ func findGitRoot() (string, error) {
 	return strings.TrimSpace(string(output)), nil
 }
 
-func (g *GitClient) runGitCommand(args ...string) ([]byte, error) {
+// newCommand builds a git command for this repository. Terminal prompts are
+// always disabled: git runs under the alt screen, so a prompt on the tty
+// would block invisibly.
+func (g *GitClient) newCommand(args ...string) *exec.Cmd {
 	cmd := exec.Command("git", args...)
 	if g.RepoPath != "" {
 		cmd.Dir = g.RepoPath
 	}
-	return cmd.Output()
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	cmd.Env = append(cmd.Env, commandEnv...)
+	if g.indexFile != "" {
+		cmd.Env = append(cmd.Env, "GIT_INDEX_FILE="+g.indexFile)
+	}
+	return cmd
+}
+
+func (g *GitClient) runGitCommand(args ...string) ([]byte, error) {
+	return g.newCommand(args...).Output()
 }
 
 func (g *GitClient) runGitCommandCombinedOutput(args ...string) ([]byte, error) {
-	cmd := exec.Command("git", args...)
-	if g.RepoPath != "" {
-		cmd.Dir = g.RepoPath
-	}
-	return cmd.CombinedOutput()
+	return g.newCommand(args...).CombinedOutput()
 }
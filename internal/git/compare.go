@@ -0,0 +1,142 @@
+package git
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CommitInfo is a commit listed when comparing two refs.
+type CommitInfo struct {
+	Hash    string
+	Author  string
+	Date    time.Time
+	Summary string
+}
+
+// CompareFile is a file changed between two refs.
+type CompareFile struct {
+	Status  string // first letter of the git status, e.g. "M", "A", "D" or "R"
+	Path    string
+	OldPath string // name before a rename or copy, "" otherwise
+}
+
+func ListRefs() ([]string, error) {
+	return NewGitClient("").ListRefs()
+}
+
+// ListRefs lists the local branches, remote branches and tags, by their
+// short names, that can be compared.
+func (g *GitClient) ListRefs() ([]string, error) {
+	output, err := g.runGitCommand("for-each-ref", "--format=%(refname:short)%00%(symref)",
+		"refs/heads", "refs/remotes", "refs/tags")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list refs: %w", err)
+	}
+	var refs []string
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		name, symref, _ := strings.Cut(line, "\x00")
+		// skip origin/HEAD and the like, they name another ref of the list
+		if name == "" || symref != "" {
+			continue
+		}
+		refs = append(refs, name)
+	}
+	return refs, nil
+}
+
+func IsCommitish(ref string) bool {
+	return NewGitClient("").IsCommitish(ref)
+}
+
+// IsCommitish reports whether ref names a commit, be it a branch, a tag or a
+// commit hash.
+func (g *GitClient) IsCommitish(ref string) bool {
+	_, err := g.runGitCommand("rev-parse", "--verify", "--quiet", ref+"^{commit}")
+	return err == nil
+}
+
+const compareCommitFormat = "%H%x00%an%x00%at%x00%s"
+
+func CommitsBetween(base, target string) ([]CommitInfo, error) {
+	return NewGitClient("").CommitsBetween(base, target)
+}
+
+// CommitsBetween lists the commits of target that base doesn't have, newest
+// first.
+func (g *GitClient) CommitsBetween(base, target string) ([]CommitInfo, error) {
+	output, err := g.runGitCommand("log", "--format="+compareCommitFormat, base+".."+target, "--")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list commits of %s not in %s: %w", target, base, err)
+	}
+	return parseCommits(string(output)), nil
+}
+
+// parseCommits parses git log output in compareCommitFormat, a line per
+// commit.
+func parseCommits(output string) []CommitInfo {
+	var commits []CommitInfo
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Split(line, "\x00")
+		if len(fields) < 4 || fields[0] == "" {
+			continue
+		}
+		commit := CommitInfo{Hash: fields[0], Author: fields[1], Summary: fields[3]}
+		if ts, err := strconv.ParseInt(fields[2], 10, 64); err == nil {
+			commit.Date = time.Unix(ts, 0)
+		}
+		commits = append(commits, commit)
+	}
+	return commits
+}
+
+func CompareFiles(base, target string) ([]CompareFile, error) {
+	return NewGitClient("").CompareFiles(base, target)
+}
+
+// CompareFiles lists the files target changed since it forked from base,
+// which is what merging target into base would bring in.
+func (g *GitClient) CompareFiles(base, target string) ([]CompareFile, error) {
+	output, err := g.runGitCommand("diff", "--name-status", "-z", "-M", "--no-ext-diff", base+"..."+target, "--")
+	if err != nil {
+		return nil, fmt.Errorf("failed to compare %s with %s: %w", target, base, err)
+	}
+	return parseNameStatus(string(output)), nil
+}
+
+// parseNameStatus parses git diff --name-status -z output: a status followed
+// by the path, or by the old and new paths for renames and copies.
+func parseNameStatus(output string) []CompareFile {
+	var files []CompareFile
+	fields := strings.Split(output, "\x00")
+	for i := 0; i+1 < len(fields); i += 2 {
+		if fields[i] == "" {
+			break
+		}
+		file := CompareFile{Status: fields[i][:1], Path: fields[i+1]}
+		if (file.Status == "R" || file.Status == "C") && i+2 < len(fields) {
+			file.OldPath, file.Path = fields[i+1], fields[i+2]
+			i++
+		}
+		files = append(files, file)
+	}
+	return files
+}
+
+func RangeDiff(base, target string, paths []string, opts DiffOptions) (string, error) {
+	return NewGitClient("").RangeDiff(base, target, paths, opts)
+}
+
+// RangeDiff returns the changes target made since it forked from base,
+// limited to paths when given.
+func (g *GitClient) RangeDiff(base, target string, paths []string, opts DiffOptions) (string, error) {
+	args := append([]string{"diff", "-M", "--no-ext-diff"}, opts.Flags()...)
+	args = append(args, base+"..."+target, "--")
+	args = append(args, paths...)
+	output, err := g.runGitCommand(args...)
+	if err != nil {
+		return "", fmt.Errorf("failed to diff %s against %s: %w", target, base, err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
@@ -0,0 +1,50 @@
+package git
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestCompareRefs(t *testing.T) {
+	root := fixtureRepo(t, 10)
+	client := NewGitClient(root)
+	mustGit(t, client, "tag", "v1")
+	mustGit(t, client, "branch", "base")
+	mustGit(t, client, "checkout", "-q", "-b", "feature")
+	mustGit(t, client, "commit", "-q", "-am", "modify")
+	mustGit(t, client, "mv", "dir1/file1.txt", "moved.txt")
+	mustGit(t, client, "commit", "-q", "-m", "rename")
+	mustGit(t, client, "checkout", "-q", "base")
+	writeFile(t, root, "dir2/file2.txt", "base\n")
+	mustGit(t, client, "commit", "-q", "-am", "base change")
+
+	if !client.IsCommitish("v1") || client.IsCommitish("nope") {
+		t.Fatal("expected v1 alone to name a commit")
+	}
+	refs, err := client.ListRefs()
+	if err != nil || !slices.Contains(refs, "feature") || !slices.Contains(refs, "v1") {
+		t.Fatalf("expected branches and tags, got %v, %v", refs, err)
+	}
+
+	ahead, err := client.CommitsBetween("base", "feature")
+	if err != nil || len(ahead) != 2 || ahead[0].Summary != "rename" {
+		t.Fatalf("unexpected commits of feature %+v, %v", ahead, err)
+	}
+	behind, err := client.CommitsBetween("feature", "base")
+	if err != nil || len(behind) != 1 || behind[0].Summary != "base change" {
+		t.Fatalf("unexpected commits of base %+v, %v", behind, err)
+	}
+
+	// the change made on base since feature forked is left out
+	files, err := client.CompareFiles("base", "feature")
+	want := []CompareFile{{Status: "M", Path: "dir0/file0.txt"}, {Status: "R", Path: "moved.txt", OldPath: "dir1/file1.txt"}}
+	if err != nil || !slices.Equal(files, want) {
+		t.Fatalf("CompareFiles = %+v, %v; want %+v", files, err, want)
+	}
+
+	diff, err := client.RangeDiff("base", "feature", []string{"dir1/file1.txt", "moved.txt"}, DiffOptions{})
+	if err != nil || !strings.Contains(diff, "rename from dir1/file1.txt") || strings.Contains(diff, "file0") {
+		t.Fatalf("expected the rename alone, got %q, %v", diff, err)
+	}
+}
@@ -2,7 +2,6 @@ package git
 
 import (
 	"fmt"
-  "os/exec"	
 	"os"
 	"strings"
 )
@@ -14,52 +13,73 @@ type FileItem struct {
 	Selected bool
 }
 
-func GetModifiedFiles() ([]FileItem, error) {
-	return NewGitClient("").GetModifiedFiles()
+// StatusOptions control how the status of the worktree is read; the zero
+// value lists untracked files like git status does.
+type StatusOptions struct {
+	// SkipUntracked leaves untracked files out, sparing git the scan of the
+	// whole worktree for them.
+	SkipUntracked bool
+	// FSMonitor asks git's builtin file system monitor which files changed
+	// instead of checking every tracked file.
+	FSMonitor bool
+	// UntrackedCache lets git remember which directories hold no new files.
+	UntrackedCache bool
+	// Large marks a repository read the cheap way, where actions on many
+	// files should run as one git command.
+	Large bool
 }
 
-func (g *GitClient) GetModifiedFiles() ([]FileItem, error) {
-	stagedFiles := make(map[string]bool)
-
-	stagedOutput, _ := g.runGitCommand("diff", "--cached", "--name-status")
-	if len(stagedOutput) > 0 {
-		lines := strings.Split(strings.TrimSpace(string(stagedOutput)), "\n")
-		for _, line := range lines {
-			parts := strings.Fields(line)
-			if len(parts) >= 2 {
-				stagedFiles[parts[1]] = true
-			}
-		}
+// Args returns the arguments of the git status command for these options.
+func (o StatusOptions) Args() []string {
+	var args []string
+	if o.FSMonitor {
+		args = append(args, "-c", "core.fsmonitor=true")
+	}
+	if o.UntrackedCache {
+		args = append(args, "-c", "core.untrackedCache=true")
 	}
+	args = append(args, "status", "--porcelain", "-z")
+	if o.SkipUntracked {
+		args = append(args, "--untracked-files=no")
+	}
+	return args
+}
+
+func GetModifiedFiles(opts StatusOptions) ([]FileItem, error) {
+	return NewGitClient("").GetModifiedFiles(opts)
+}
 
-	output, err := g.runGitCommand("status", "--porcelain")
+// GetModifiedFiles lists the changed files with a single git status. A file
+// counts as staged when its index column shows a change.
+func (g *GitClient) GetModifiedFiles(opts StatusOptions) ([]FileItem, error) {
+	output, err := g.runGitCommand(opts.Args()...)
 	if err != nil {
 		return nil, err
 	}
+	return parseStatus(string(output)), nil
+}
 
+// parseStatus parses the output of git status --porcelain -z. Renames and
+// copies are followed by their source path, which is skipped.
+func parseStatus(output string) []FileItem {
 	var files []FileItem
-	if len(output) > 0 {
-		lines := strings.Split(strings.TrimSpace(string(output)), "\n")
-		for _, line := range lines {
-			if len(line) < 4 {
-				continue
-			}
-
-			status := strings.TrimSpace(line[:2])
-			filename := strings.TrimSpace(line[3:])
-			if fields := strings.Fields(line); len(fields) >= 2 {
-				filename = strings.Join(fields[1:], " ")
-			}
-
-			files = append(files, FileItem{
-				Name:   filename,
-				Status: status,
-				Staged: stagedFiles[filename],
-			})
+	entries := strings.Split(output, "\x00")
+	for i := 0; i < len(entries); i++ {
+		entry := entries[i]
+		if len(entry) < 4 {
+			continue
+		}
+		x, y := entry[0], entry[1]
+		if x == 'R' || x == 'C' || y == 'R' || y == 'C' {
+			i++
 		}
+		files = append(files, FileItem{
+			Name:   entry[3:],
+			Status: strings.TrimSpace(entry[:2]),
+			Staged: x != ' ' && x != '?' && x != '!',
+		})
 	}
-
-	return files, nil
+	return files
 }
 
 func DiscardChanges(filename string) error {
@@ -67,11 +87,7 @@ func DiscardChanges(filename string) error {
 }
 
 func (g *GitClient) DiscardChanges(filename string) error {
-	cmd := exec.Command("git", "ls-files", "--error-unmatch", filename)
-	if g.RepoPath != "" {
-		cmd.Dir = g.RepoPath
-	}
-	if err := cmd.Run(); err != nil {
+	if err := g.newCommand("ls-files", "--error-unmatch", filename).Run(); err != nil {
 		if err := os.Remove(filename); err != nil {
 			return fmt.Errorf("failed to remove untracked file: %w", err)
 		}
@@ -81,3 +97,22 @@ func (g *GitClient) DiscardChanges(filename string) error {
 	_, err := g.runGitCommandCombinedOutput("checkout", "--", filename)
 	return err
 }
+
+func TrackedFiles() ([]string, error) {
+	return NewGitClient("").TrackedFiles()
+}
+
+// TrackedFiles lists the files in the index, relative to the repository root.
+func (g *GitClient) TrackedFiles() ([]string, error) {
+	output, err := g.runGitCommand("ls-files", "-z", "--cached")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tracked files: %w", err)
+	}
+	var files []string
+	for _, file := range strings.Split(string(output), "\x00") {
+		if file != "" {
+			files = append(files, file)
+		}
+	}
+	return files, nil
+}
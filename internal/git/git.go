@@ -12,3 +12,9 @@ func IsGitRepository() bool {
 func InitRepository() error {
 	return exec.Command("git", "init").Run()
 }
+
+// RepoRoot returns the top-level directory of the current repository, which
+// the paths git reports are relative to.
+func RepoRoot() string {
+	return NewGitClient("").RepoPath
+}
@@ -1,6 +1,9 @@
 package git
 
-import "testing"
+import (
+	"strings"
+	"testing"
+)
 
 func TestGetStashRef(t *testing.T) {
 	cases := map[string]string{
@@ -15,3 +18,243 @@ func TestGetStashRef(t *testing.T) {
 		}
 	}
 }
+
+func TestPushOptionsArgs(t *testing.T) {
+	opts := PushOptions{
+		Remote:         "upstream",
+		Branch:         "feature",
+		ForceWithLease: true,
+		ExpectedSHA:    "abc123",
+		Tags:           true,
+		DryRun:         true,
+	}
+	got := strings.Join(opts.Args(), " ")
+	want := "push --dry-run --force-with-lease=refs/heads/feature:abc123 --tags upstream HEAD:refs/heads/feature"
+	if got != want {
+		t.Fatalf("Args() = %q; want %q", got, want)
+	}
+}
+
+func TestPullArgs(t *testing.T) {
+	tests := []struct {
+		strategy, rebase, ff string
+		want                 string
+	}{
+		{PullFFOnly, "true", "", "merge --ff-only @{u}"},
+		{PullAutostash, "", "", "rebase --autostash @{u}"},
+		{PullDefault, "merges", "", "rebase --rebase-merges @{u}"},
+		{PullDefault, "false", "only", "merge --ff-only @{u}"},
+		{PullDefault, "", "", "merge @{u}"},
+	}
+	for _, tt := range tests {
+		if got := strings.Join(PullArgs(tt.strategy, tt.rebase, tt.ff), " "); got != tt.want {
+			t.Errorf("PullArgs(%q, %q, %q) = %q; want %q", tt.strategy, tt.rebase, tt.ff, got, tt.want)
+		}
+	}
+}
+
+func TestStashOptionsArgs(t *testing.T) {
+	tests := []struct {
+		opts StashOptions
+		want string
+	}{
+		{StashOptions{Message: "wip", Staged: true}, "stash push -m wip --staged"},
+		{StashOptions{Message: "wip", KeepIndex: true, IncludeUntracked: true, Paths: []string{"a.go"}}, "stash push -m wip --keep-index --include-untracked"},
+	}
+	for _, tt := range tests {
+		if got := strings.Join(tt.opts.Args(), " "); got != tt.want {
+			t.Errorf("Args(%+v) = %q; want %q", tt.opts, got, tt.want)
+		}
+	}
+}
+
+func TestParseStashInfos(t *testing.T) {
+	output := "\x1estash@{0}\x001700000000\x00a b c\n\nmain.go\ngo.mod\n\x1estash@{1}\x001600000000\x00a b\n"
+	infos := parseStashInfos(output)
+	if len(infos) != 2 {
+		t.Fatalf("expected 2 stashes, got %+v", infos)
+	}
+	if infos[0].Ref != "stash@{0}" || !infos[0].Untracked || strings.Join(infos[0].Files, ",") != "main.go,go.mod" {
+		t.Fatalf("unexpected first stash %+v", infos[0])
+	}
+	if infos[1].Untracked || len(infos[1].Files) != 0 || infos[1].Date.Unix() != 1600000000 {
+		t.Fatalf("unexpected second stash %+v", infos[1])
+	}
+}
+
+func TestIgnorePatterns(t *testing.T) {
+	tests := []struct {
+		path, dir string
+		want      string
+	}{
+		{"build/out.log", "", "/build/out.log *.log /build/"},
+		{"build/out.log", "build", "/out.log *.log"},
+		{"a/b/.env", "a", "/b/.env /b/"},
+		{"notes", "", "/notes"},
+		{"tmp/cache/", "", "/tmp/cache/ /tmp/"},
+	}
+	for _, tt := range tests {
+		got := strings.Join(IgnorePatterns(tt.path, tt.dir), " ")
+		if got != tt.want {
+			t.Errorf("IgnorePatterns(%q, %q) = %q; want %q", tt.path, tt.dir, got, tt.want)
+		}
+	}
+}
+
+func TestParseBlame(t *testing.T) {
+	output := strings.Join([]string{
+		"1111111111111111111111111111111111111111 3 1 1",
+		"author Ada",
+		"author-time 1700000000",
+		"summary Rename things",
+		"previous 2222222222222222222222222222222222222222 old.go",
+		"filename new.go",
+		"\tpackage main",
+		"0000000000000000000000000000000000000000 2 2 1",
+		"author Not Committed Yet",
+		"summary Version of new.go from new.go",
+		"filename new.go",
+		"\t",
+		"",
+	}, "\n")
+	lines := parseBlame(output)
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %+v", lines)
+	}
+	first := lines[0]
+	if first.Author != "Ada" || first.OrigLine != 3 || first.Previous != "2222222222222222222222222222222222222222" ||
+		first.PreviousPath != "old.go" || first.Path != "new.go" || first.Text != "package main" || first.Date.Unix() != 1700000000 {
+		t.Fatalf("unexpected first line %+v", first)
+	}
+	if first.Uncommitted() || !lines[1].Uncommitted() || lines[1].Text != "" {
+		t.Fatalf("unexpected second line %+v", lines[1])
+	}
+}
+
+func TestDiffOptionsArgs(t *testing.T) {
+	tests := []struct {
+		opts DiffOptions
+		want string
+	}{
+		{DiffOptions{}, "diff -U3"},
+		{DiffOptions{IgnoreWhitespace: true, Context: 10}, "diff -U10 --ignore-all-space"},
+	}
+	for _, tt := range tests {
+		if got := strings.Join(tt.opts.Args(), " "); got != tt.want {
+			t.Errorf("Args() = %q; want %q", got, tt.want)
+		}
+	}
+}
+
+func TestParseStatus(t *testing.T) {
+	out := "M  staged.go\x00 M has space.go\x00R  new.go\x00old.go\x00?? notes/\x00UU conflict.go\x00"
+	files := parseStatus(out)
+	want := []FileItem{
+		{Name: "staged.go", Status: "M", Staged: true},
+		{Name: "has space.go", Status: "M"},
+		{Name: "new.go", Status: "R", Staged: true},
+		{Name: "notes/", Status: "??"},
+		{Name: "conflict.go", Status: "UU", Staged: true},
+	}
+	if len(files) != len(want) {
+		t.Fatalf("expected %d files, got %+v", len(want), files)
+	}
+	for i := range want {
+		if files[i] != want[i] {
+			t.Errorf("file %d = %+v; want %+v", i, files[i], want[i])
+		}
+	}
+}
+
+func TestStatusOptionsArgs(t *testing.T) {
+	opts := StatusOptions{SkipUntracked: true, UntrackedCache: true}
+	got := strings.Join(opts.Args(), " ")
+	want := "-c core.untrackedCache=true status --porcelain -z --untracked-files=no"
+	if got != want {
+		t.Fatalf("Args() = %q; want %q", got, want)
+	}
+}
+
+func TestIsPlainPager(t *testing.T) {
+	for _, pager := range []string{"less -R", "/usr/bin/more", "cat", ""} {
+		if !isPlainPager(pager) {
+			t.Errorf("expected %q to be a plain pager", pager)
+		}
+	}
+	for _, pager := range []string{"delta --side-by-side", "diff-so-fancy | less"} {
+		if isPlainPager(pager) {
+			t.Errorf("expected %q to render diffs", pager)
+		}
+	}
+}
+
+func TestParseAheadBehind(t *testing.T) {
+	ahead, behind, err := parseAheadBehind("3\t5\n")
+	if err != nil || ahead != 3 || behind != 5 {
+		t.Fatalf("parseAheadBehind = %d, %d, %v; want 3, 5, nil", ahead, behind, err)
+	}
+	if _, _, err := parseAheadBehind("fatal"); err == nil {
+		t.Fatalf("expected error for malformed output")
+	}
+}
+
+func TestParseBranchInfos(t *testing.T) {
+	out := "*\x00main\x00origin/main\x00ahead 2, behind 1\x001700000000\x00Ada\n" +
+		" \x00old\x00origin/old\x00gone\x001600000000\x00Bob\n" +
+		" \x00local\x00\x00\x001650000000\x00Cy\n"
+	infos := parseBranchInfos(out)
+	if len(infos) != 3 {
+		t.Fatalf("expected 3 branches, got %d", len(infos))
+	}
+	if !infos[0].Current || infos[0].Ahead != 2 || infos[0].Behind != 1 || infos[0].Upstream != "origin/main" {
+		t.Fatalf("unexpected main info: %+v", infos[0])
+	}
+	if !infos[1].Gone || infos[1].Current {
+		t.Fatalf("expected old to have a gone upstream: %+v", infos[1])
+	}
+	if infos[2].Upstream != "" || infos[2].LastCommit.Unix() != 1650000000 || infos[2].Author != "Cy" {
+		t.Fatalf("unexpected local info: %+v", infos[2])
+	}
+}
+
+func TestParseRemoteBranches(t *testing.T) {
+	out := "refs/remotes/origin/HEAD\x00refs/remotes/origin/main\n" +
+		"refs/remotes/origin/main\x00\n" +
+		"refs/remotes/upstream/feature/login\x00\n"
+	branches := parseRemoteBranches(out)
+	if len(branches) != 2 {
+		t.Fatalf("expected 2 remote branches, got %d", len(branches))
+	}
+	if branches[1].Remote != "upstream" || branches[1].Name != "feature/login" || branches[1].Ref() != "upstream/feature/login" {
+		t.Fatalf("unexpected remote branch: %+v", branches[1])
+	}
+}
+
+func TestLogSearchKindArg(t *testing.T) {
+	cases := map[LogSearchKind]string{
+		LogSearchPickaxe: "-Sfoo bar",
+		LogSearchRegex:   "-Gfoo bar",
+		LogSearchMessage: "--grep=foo bar",
+		LogSearchAuthor:  "--author=foo bar",
+	}
+	for kind, want := range cases {
+		if got := kind.Arg("foo bar"); got != want {
+			t.Fatalf("%s.Arg = %q; want %q", kind, got, want)
+		}
+	}
+}
+
+func TestLogLineHash(t *testing.T) {
+	cases := map[string]string{
+		"* 052d977 [user-034] Size views": "052d977",
+		"| * 4fb7bb0 (HEAD -> main) Add":  "4fb7bb0",
+		"|/":                              "",
+		"* | abc1234 Merge branch 'x'":    "abc1234",
+		"* not-a-hash message":            "",
+	}
+	for line, want := range cases {
+		if got := LogLineHash(line); got != want {
+			t.Fatalf("LogLineHash(%q) = %q; want %q", line, got, want)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FileCommit is a commit that changed a file.
+type FileCommit struct {
+	Hash    string
+	Author  string
+	Date    time.Time
+	Summary string
+	Path    string // name of the file in that commit, which differs before a rename
+}
+
+const fileCommitFormat = "%x1e%H%x00%an%x00%at%x00%s"
+
+func FileHistory(path string) ([]FileCommit, error) {
+	return NewGitClient("").FileHistory(path)
+}
+
+// FileHistory lists the commits that changed path, newest first, following
+// it across renames.
+func (g *GitClient) FileHistory(path string) ([]FileCommit, error) {
+	output, err := g.runGitCommand("log", "--follow", "--name-only", "--format="+fileCommitFormat, "--", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get history of %s: %w", path, err)
+	}
+	return parseFileHistory(string(output)), nil
+}
+
+// parseFileHistory parses git log output in fileCommitFormat with
+// --name-only: a record per commit, its header line followed by the name of
+// the file.
+func parseFileHistory(output string) []FileCommit {
+	var commits []FileCommit
+	for _, record := range strings.Split(output, "\x1e") {
+		lines := strings.Split(strings.TrimSpace(record), "\n")
+		fields := strings.Split(lines[0], "\x00")
+		if len(fields) < 4 || fields[0] == "" {
+			continue
+		}
+		commit := FileCommit{Hash: fields[0], Author: fields[1], Summary: fields[3]}
+		if ts, err := strconv.ParseInt(fields[2], 10, 64); err == nil {
+			commit.Date = time.Unix(ts, 0)
+		}
+		for _, line := range lines[1:] {
+			if line = strings.TrimSpace(line); line != "" {
+				commit.Path = line
+			}
+		}
+		commits = append(commits, commit)
+	}
+
+	// merges list no file, the name is the one of the commit merged before
+	// them, or after them for the oldest one
+	for i := len(commits) - 1; i >= 0; i-- {
+		if commits[i].Path == "" && i+1 < len(commits) {
+			commits[i].Path = commits[i+1].Path
+		}
+	}
+	for i := range commits {
+		if commits[i].Path == "" && i > 0 {
+			commits[i].Path = commits[i-1].Path
+		}
+	}
+	return commits
+}
+
+func CommitFileDiff(hash string, paths []string, opts DiffOptions) (string, error) {
+	return NewGitClient("").CommitFileDiff(hash, paths, opts)
+}
+
+// CommitFileDiff returns the change commit hash made to the given files.
+// Passing a file's names before and after a rename shows the rename.
+func (g *GitClient) CommitFileDiff(hash string, paths []string, opts DiffOptions) (string, error) {
+	args := append([]string{"show", "--format=medium", "-M", "--no-ext-diff"}, opts.Flags()...)
+	args = append(args, hash, "--")
+	args = append(args, paths...)
+	output, err := g.runGitCommand(args...)
+	if err != nil {
+		return "", fmt.Errorf("failed to show %s in %s: %w", strings.Join(paths, ", "), hash, err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+func RestoreFileFrom(rev, revPath, path string, stage bool) error {
+	return NewGitClient("").RestoreFileFrom(rev, revPath, path, stage)
+}
+
+// RestoreFileFrom puts the content path had in commit rev, where it was
+// named revPath, back in the worktree, and in the index too when stage is
+// set. Local changes to path are overwritten.
+func (g *GitClient) RestoreFileFrom(rev, revPath, path string, stage bool) error {
+	if revPath == path {
+		args := []string{"restore", "--source=" + rev, "--worktree"}
+		if stage {
+			args = append(args, "--staged")
+		}
+		output, err := g.runGitCommandCombinedOutput(append(args, "--", path)...)
+		if err != nil {
+			return fmt.Errorf("failed to restore %s: %w: %s", path, err, strings.TrimSpace(string(output)))
+		}
+		return nil
+	}
+
+	// the file was renamed since: check the old blob out under the new name
+	// through an index entry, so filters, the file mode and symlinks are
+	// handled as by any checkout
+	output, err := g.runGitCommand("ls-tree", "-z", rev, "--", revPath)
+	if err != nil {
+		return fmt.Errorf("failed to read %s at %s: %w", revPath, rev, err)
+	}
+	mode, object, ok := parseLsTreeEntry(string(output))
+	if !ok {
+		return fmt.Errorf("failed to read %s at %s: no such file", revPath, rev)
+	}
+
+	index := g
+	if !stage {
+		// leave the index alone, go through a throwaway one
+		dir, err := os.MkdirTemp("", "froggit-restore")
+		if err != nil {
+			return fmt.Errorf("failed to restore %s: %w", path, err)
+		}
+		defer os.RemoveAll(dir)
+		index = &GitClient{RepoPath: g.RepoPath, indexFile: filepath.Join(dir, "index")}
+	}
+	if output, err := index.runGitCommandCombinedOutput("update-index", "--add", "--cacheinfo", mode+","+object+","+path); err != nil {
+		return fmt.Errorf("failed to restore %s: %w: %s", path, err, strings.TrimSpace(string(output)))
+	}
+	if output, err := index.runGitCommandCombinedOutput("checkout-index", "-f", "--", path); err != nil {
+		return fmt.Errorf("failed to restore %s: %w: %s", path, err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// parseLsTreeEntry parses the mode and object name of a git ls-tree -z entry.
+func parseLsTreeEntry(output string) (mode, object string, ok bool) {
+	info, _, ok := strings.Cut(output, "\t")
+	fields := strings.Fields(info)
+	if !ok || len(fields) != 3 {
+		return "", "", false
+	}
+	return fields[0], fields[2], true
+}
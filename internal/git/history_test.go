@@ -0,0 +1,75 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFileHistoryFollowsRenames(t *testing.T) {
+	root := fixtureRepo(t, 10)
+	client := NewGitClient(root)
+	mustGit(t, client, "commit", "-q", "-am", "modify")
+	mustGit(t, client, "mv", "dir1/file1.txt", "moved.txt")
+	mustGit(t, client, "commit", "-q", "-m", "rename")
+
+	commits, err := client.FileHistory("moved.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(commits) != 2 || commits[0].Summary != "rename" || commits[0].Path != "moved.txt" || commits[1].Path != "dir1/file1.txt" {
+		t.Fatalf("unexpected history %+v", commits)
+	}
+
+	diff, err := client.CommitFileDiff(commits[0].Hash, []string{"moved.txt", "dir1/file1.txt"}, DiffOptions{})
+	if err != nil || !strings.Contains(diff, "rename from dir1/file1.txt") {
+		t.Fatalf("expected a rename diff, got %q, %v", diff, err)
+	}
+
+	os.WriteFile(filepath.Join(root, "moved.txt"), []byte("local\n"), 0o644)
+	if err := client.RestoreFileFrom(commits[1].Hash, commits[1].Path, "moved.txt", true); err != nil {
+		t.Fatalf("RestoreFileFrom: %v", err)
+	}
+	content, _ := os.ReadFile(filepath.Join(root, "moved.txt"))
+	if string(content) != "tracked\n" {
+		t.Fatalf("moved.txt = %q; want the first version", content)
+	}
+	files, _ := client.GetModifiedFiles(StatusOptions{SkipUntracked: true})
+	if len(files) != 0 {
+		t.Fatalf("expected the restored file to match HEAD and leave nothing to commit, got %+v", files)
+	}
+}
+
+func TestRestoreFileFromKeepsModeAndIndex(t *testing.T) {
+	root := fixtureRepo(t, 10)
+	client := NewGitClient(root)
+	os.Chmod(filepath.Join(root, "dir1/file1.txt"), 0o755)
+	mustGit(t, client, "commit", "-q", "-am", "executable")
+	mustGit(t, client, "mv", "dir1/file1.txt", "moved.txt")
+	writeFile(t, root, "moved.txt", "renamed\n")
+	mustGit(t, client, "add", "moved.txt")
+	mustGit(t, client, "commit", "-q", "-m", "rename")
+
+	if err := client.RestoreFileFrom("HEAD~1", "dir1/file1.txt", "moved.txt", false); err != nil {
+		t.Fatalf("RestoreFileFrom: %v", err)
+	}
+	info, err := os.Stat(filepath.Join(root, "moved.txt"))
+	if err != nil || info.Mode().Perm()&0o100 == 0 {
+		t.Fatalf("expected the restored file to stay executable, got %v, %v", info, err)
+	}
+	staged, _ := client.runGitCommand("diff", "--cached", "--name-only")
+	if len(staged) != 0 {
+		t.Fatalf("expected the index to be left alone, got %q staged", staged)
+	}
+}
+
+func TestParseFileHistoryMergePaths(t *testing.T) {
+	output := "\x1ec3\x00f\x001\x00merge\n" +
+		"\x1eb2\x00f\x001\x00rename\n\nnew.txt\n" +
+		"\x1ea1\x00f\x001\x00merge early\n"
+	commits := parseFileHistory(output)
+	if len(commits) != 3 || commits[0].Path != "new.txt" || commits[2].Path != "new.txt" {
+		t.Fatalf("unexpected paths %+v", commits)
+	}
+}
@@ -0,0 +1,206 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+func IgnorePaths(paths []string) (int, error) {
+	return NewGitClient("").IgnorePaths(paths)
+}
+
+// IgnorePaths adds the paths to the .gitignore at the root of the
+// repository, anchored so they only match there. Paths already listed are
+// skipped; it returns how many were added.
+func (g *GitClient) IgnorePaths(paths []string) (int, error) {
+	patterns := make([]string, len(paths))
+	for i, p := range paths {
+		patterns[i] = "/" + escapeIgnorePattern(p)
+	}
+	return g.AddIgnorePatterns(filepath.Join(g.RepoPath, ".gitignore"), patterns)
+}
+
+func AddIgnorePatterns(file string, patterns []string) (int, error) {
+	return NewGitClient("").AddIgnorePatterns(file, patterns)
+}
+
+// AddIgnorePatterns appends the patterns to the ignore file, creating it if
+// needed. Patterns it lists already are skipped; it returns how many were
+// added.
+func (g *GitClient) AddIgnorePatterns(file string, patterns []string) (int, error) {
+	name := filepath.Base(file)
+	content, err := os.ReadFile(file)
+	if err != nil && !os.IsNotExist(err) {
+		return 0, fmt.Errorf("failed to read %s: %w", name, err)
+	}
+
+	existing := make(map[string]bool)
+	for _, line := range strings.Split(string(content), "\n") {
+		existing[strings.TrimSpace(line)] = true
+	}
+
+	var add strings.Builder
+	if len(content) > 0 && !strings.HasSuffix(string(content), "\n") {
+		add.WriteString("\n")
+	}
+	added := 0
+	for _, pattern := range patterns {
+		if existing[pattern] {
+			continue
+		}
+		existing[pattern] = true
+		add.WriteString(pattern + "\n")
+		added++
+	}
+	if added == 0 {
+		return 0, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+		return 0, fmt.Errorf("failed to update %s: %w", name, err)
+	}
+	f, err := os.OpenFile(file, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return 0, fmt.Errorf("failed to update %s: %w", name, err)
+	}
+	defer f.Close()
+	if _, err := f.WriteString(add.String()); err != nil {
+		return 0, fmt.Errorf("failed to update %s: %w", name, err)
+	}
+	return added, nil
+}
+
+// IgnoreTarget is a file ignore patterns can be written to.
+type IgnoreTarget struct {
+	Label string // how the file is shown, e.g. "src/.gitignore"
+	File  string // path of the file
+	Dir   string // directory its patterns are relative to, "" for the root
+}
+
+func IgnoreTargets(path string) []IgnoreTarget {
+	return NewGitClient("").IgnoreTargets(path)
+}
+
+// IgnoreTargets lists the files that can ignore path: the root .gitignore,
+// the .gitignore next to path when it is in a subdirectory, and the
+// repository's info/exclude, which isn't shared with others.
+func (g *GitClient) IgnoreTargets(path string) []IgnoreTarget {
+	targets := []IgnoreTarget{{Label: ".gitignore", File: filepath.Join(g.RepoPath, ".gitignore")}}
+	if dir := parentDir(path); dir != "" {
+		targets = append(targets, IgnoreTarget{
+			Label: dir + "/.gitignore",
+			File:  filepath.Join(g.RepoPath, dir, ".gitignore"),
+			Dir:   dir,
+		})
+	}
+	output, err := g.runGitCommand("rev-parse", "--path-format=absolute", "--git-path", "info/exclude")
+	if err == nil {
+		targets = append(targets, IgnoreTarget{Label: ".git/info/exclude", File: strings.TrimSpace(string(output))})
+	}
+	return targets
+}
+
+// IgnorePatterns returns the patterns an ignore file in dir can use to
+// ignore path: the exact path, every file with its extension, and its whole
+// directory. Patterns that make no sense for the path, such as the
+// extension of a directory, are left out.
+func IgnorePatterns(path, dir string) []string {
+	rel := path
+	if dir != "" {
+		rel = strings.TrimPrefix(path, dir+"/")
+	}
+	// untracked directories are listed as "dir/"
+	isDir := strings.HasSuffix(rel, "/")
+	patterns := []string{"/" + escapeIgnorePattern(strings.TrimSuffix(rel, "/"))}
+	if isDir {
+		patterns[0] += "/"
+	}
+	if ext := filepath.Ext(rel); !isDir && ext != "" && ext != filepath.Base(rel) {
+		patterns = append(patterns, "*"+escapeIgnorePattern(ext))
+	}
+	if parent := parentDir(rel); parent != "" {
+		patterns = append(patterns, "/"+escapeIgnorePattern(parent)+"/")
+	}
+	return patterns
+}
+
+// parentDir returns the directory holding path, "" at the root.
+func parentDir(path string) string {
+	dir := filepath.ToSlash(filepath.Dir(strings.TrimSuffix(path, "/")))
+	if dir == "." {
+		return ""
+	}
+	return dir
+}
+
+// IgnoreMatch explains whether a path is ignored.
+type IgnoreMatch struct {
+	Path    string
+	Source  string // ignore file holding the deciding pattern, "" when none matches
+	Line    int
+	Pattern string
+	Tracked bool // tracked files aren't ignored, whatever the patterns say
+}
+
+// Ignored reports whether the path is ignored: a pattern matches it and
+// doesn't re-include it with "!".
+func (m IgnoreMatch) Ignored() bool {
+	return m.Source != "" && !strings.HasPrefix(m.Pattern, "!") && !m.Tracked
+}
+
+func ExplainIgnore(path string) (IgnoreMatch, error) {
+	return NewGitClient("").ExplainIgnore(path)
+}
+
+// ExplainIgnore finds the pattern that decides whether path is ignored,
+// using git check-ignore -v.
+func (g *GitClient) ExplainIgnore(path string) (IgnoreMatch, error) {
+	match := IgnoreMatch{Path: path}
+	cmd := g.newCommand("check-ignore", "-v", "-n", "--no-index", "--", path)
+	output, err := cmd.Output()
+	var exitErr *exec.ExitError
+	// check-ignore exits with 1 when the path isn't ignored
+	if err != nil && !(errors.As(err, &exitErr) && exitErr.ExitCode() == 1) {
+		return match, fmt.Errorf("failed to check %s: %w", path, err)
+	}
+	match.Source, match.Line, match.Pattern = parseCheckIgnore(string(output))
+
+	if output, err := g.runGitCommand("ls-files", "--", path); err == nil {
+		match.Tracked = strings.TrimSpace(string(output)) != ""
+	}
+	return match, nil
+}
+
+// parseCheckIgnore parses a line of git check-ignore -v -n, such as
+// ".gitignore:3:*.log\tdebug.log", or "::\tmain.go" when nothing matches.
+func parseCheckIgnore(output string) (source string, line int, pattern string) {
+	rule, _, _ := strings.Cut(strings.TrimRight(output, "\n"), "\t")
+	parts := strings.SplitN(rule, ":", 3)
+	if len(parts) < 3 || parts[0] == "" {
+		return "", 0, ""
+	}
+	line, _ = strconv.Atoi(parts[1])
+	return parts[0], line, parts[2]
+}
+
+// escapeIgnorePattern makes a path match literally as a .gitignore pattern.
+func escapeIgnorePattern(path string) string {
+	var b strings.Builder
+	for _, r := range path {
+		if strings.ContainsRune(`\*?[`, r) {
+			b.WriteRune('\\')
+		}
+		b.WriteRune(r)
+	}
+	escaped := b.String()
+	// trailing spaces are ignored unless escaped
+	if trimmed := strings.TrimRight(escaped, " "); len(trimmed) < len(escaped) {
+		escaped = trimmed + strings.Repeat("\\ ", len(escaped)-len(trimmed))
+	}
+	return escaped
+}
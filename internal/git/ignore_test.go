@@ -0,0 +1,62 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestIgnorePaths(t *testing.T) {
+	root := fixtureRepo(t, 10)
+	client := NewGitClient(root)
+	writeFile(t, root, ".gitignore", "*.log")
+
+	added, err := client.IgnorePaths([]string{"dir0/new0.txt", "odd[1].txt", "dir0/new0.txt"})
+	if err != nil || added != 2 {
+		t.Fatalf("IgnorePaths() = %d, %v; want 2, nil", added, err)
+	}
+	if added, _ := client.IgnorePaths([]string{"dir0/new0.txt"}); added != 0 {
+		t.Fatalf("expected a listed path to be skipped, added %d", added)
+	}
+	content, _ := os.ReadFile(filepath.Join(root, ".gitignore"))
+	if want := "*.log\n/dir0/new0.txt\n/odd\\[1].txt\n"; string(content) != want {
+		t.Fatalf(".gitignore = %q; want %q", content, want)
+	}
+
+	ignored, err := client.CheckIgnored([]string{"dir0/new0.txt", "odd[1].txt", "odd1.txt"})
+	if err != nil || !ignored["dir0/new0.txt"] || !ignored["odd[1].txt"] || ignored["odd1.txt"] {
+		t.Fatalf("unexpected ignored paths %v, %v", ignored, err)
+	}
+}
+
+func TestExplainIgnore(t *testing.T) {
+	root := fixtureRepo(t, 10)
+	client := NewGitClient(root)
+	writeFile(t, root, ".gitignore", "*.log\n!keep.log\ndir0/\n")
+
+	tests := []struct {
+		path    string
+		ignored bool
+		line    int
+	}{
+		{"debug.log", true, 1},
+		{"keep.log", false, 2},
+		{"dir0/file0.txt", false, 3}, // tracked
+		{"main.go", false, 0},
+	}
+	for _, tt := range tests {
+		match, err := client.ExplainIgnore(tt.path)
+		if err != nil {
+			t.Fatalf("ExplainIgnore(%q): %v", tt.path, err)
+		}
+		if match.Ignored() != tt.ignored || match.Line != tt.line {
+			t.Errorf("ExplainIgnore(%q) = %+v; want ignored %v by line %d", tt.path, match, tt.ignored, tt.line)
+		}
+	}
+
+	targets := client.IgnoreTargets("dir0/new0.txt")
+	if len(targets) != 3 || targets[1].Label != "dir0/.gitignore" || !strings.HasSuffix(targets[2].File, filepath.Join("info", "exclude")) {
+		t.Fatalf("unexpected ignore targets %+v", targets)
+	}
+}
@@ -1,7 +1,9 @@
 package git
 
 import (
+	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 )
 
@@ -87,30 +89,157 @@ func (g *GitClient) FetchWithConfig(silent bool) error {
 	return nil
 }
 
+// Pull strategies selectable in the config file and in the pull dialog.
+// PullDefault leaves the choice to git's own pull.rebase/pull.ff settings.
+const (
+	PullDefault   = ""
+	PullFFOnly    = "ff-only"
+	PullMerge     = "merge"
+	PullRebase    = "rebase"
+	PullAutostash = "autostash"
+)
+
+// PullStrategies lists the explicit strategies in the order the pull dialog shows them.
+var PullStrategies = []string{PullFFOnly, PullMerge, PullRebase, PullAutostash}
+
+// ErrDiverged is returned by PullWithStrategy when local and remote both have
+// new commits and the strategy cannot reconcile them.
+var ErrDiverged = errors.New("local and remote branches have diverged")
+
+// PullResult describes the state of the branch around a pull.
+type PullResult struct {
+	Ahead     int
+	Behind    int
+	Conflicts []string
+}
+
+// Diverged reports whether both sides have commits the other lacks.
+func (r PullResult) Diverged() bool {
+	return r.Ahead > 0 && r.Behind > 0
+}
+
+// PullArgs returns the arguments that bring the fetched upstream into the
+// current branch with strategy. The fetch is done beforehand, so this merges
+// or rebases onto @{u} rather than running git pull, which would fetch again.
+// rebaseConfig and ffConfig are git's pull.rebase and pull.ff settings, which
+// decide for PullDefault.
+func PullArgs(strategy, rebaseConfig, ffConfig string) []string {
+	switch strategy {
+	case PullFFOnly:
+		return []string{"merge", "--ff-only", "@{u}"}
+	case PullMerge:
+		return []string{"merge", "@{u}"}
+	case PullRebase:
+		return []string{"rebase", "@{u}"}
+	case PullAutostash:
+		return []string{"rebase", "--autostash", "@{u}"}
+	}
+
+	switch rebaseConfig {
+	case "true", "interactive", "i":
+		return []string{"rebase", "@{u}"}
+	case "merges", "m":
+		return []string{"rebase", "--rebase-merges", "@{u}"}
+	}
+	switch ffConfig {
+	case "only":
+		return []string{"merge", "--ff-only", "@{u}"}
+	case "false":
+		return []string{"merge", "--no-ff", "@{u}"}
+	}
+	return []string{"merge", "@{u}"}
+}
+
 func Pull() error {
 	return NewGitClient("").Pull()
 }
 
 func (g *GitClient) Pull() error {
-	mu.Lock()
-	if operationInProgress {
-		mu.Unlock()
-		return fmt.Errorf("another git operation is already in progress")
+	_, err := g.PullWithStrategy(PullDefault)
+	return err
+}
+
+func PullWithStrategy(strategy string) (PullResult, error) {
+	return NewGitClient("").PullWithStrategy(strategy)
+}
+
+// PullWithStrategy fetches, checks ahead/behind counts against the upstream and
+// then merges or rebases onto it with strategy. It returns ErrDiverged without touching the
+// worktree when the branches diverged and strategy would refuse or git has no
+// pull.rebase configured to decide. Conflicts left by the pull are reported in
+// the result.
+func (g *GitClient) PullWithStrategy(strategy string) (PullResult, error) {
+	var result PullResult
+
+	if err := beginOperation(); err != nil {
+		return result, err
 	}
-	operationInProgress = true
-	mu.Unlock()
+	defer endOperation()
 
-	defer func() {
-		mu.Lock()
-		operationInProgress = false
-		mu.Unlock()
-	}()
+	if output, err := g.runGitCommandCombinedOutput("fetch"); err != nil {
+		return result, fmt.Errorf("pull failed: %v - %s", err, string(output))
+	}
 
-	output, err := g.runGitCommandCombinedOutput("pull")
+	if ahead, behind, err := g.AheadBehind("HEAD", "@{u}"); err == nil {
+		result.Ahead, result.Behind = ahead, behind
+		if behind == 0 {
+			return result, nil
+		}
+		if result.Diverged() && (strategy == PullFFOnly || (strategy == PullDefault && !g.hasPullRebaseConfig())) {
+			return result, ErrDiverged
+		}
+	}
+
+	output, err := g.runGitCommandCombinedOutput(PullArgs(strategy, g.pullRebaseConfig(), g.ConfigValue("pull.ff"))...)
 	if err != nil {
-		return fmt.Errorf("pull failed: %v - %s", err, string(output))
+		result.Conflicts, _ = g.GetConflictFiles()
+		return result, fmt.Errorf("pull failed: %v - %s", err, string(output))
 	}
-	return nil
+	return result, nil
+}
+
+func (g *GitClient) hasPullRebaseConfig() bool {
+	return g.pullRebaseConfig() != ""
+}
+
+// pullRebaseConfig returns how git pull would reconcile the current branch:
+// its branch.<name>.rebase setting, or pull.rebase.
+func (g *GitClient) pullRebaseConfig() string {
+	if branch := g.CurrentBranch(); branch != "" {
+		if value := g.ConfigValue("branch." + branch + ".rebase"); value != "" {
+			return value
+		}
+	}
+	return g.ConfigValue("pull.rebase")
+}
+
+func AheadBehind(local, upstream string) (int, int, error) {
+	return NewGitClient("").AheadBehind(local, upstream)
+}
+
+// AheadBehind counts the commits local has that upstream lacks and vice versa.
+func (g *GitClient) AheadBehind(local, upstream string) (int, int, error) {
+	output, err := g.runGitCommand("rev-list", "--left-right", "--count", local+"..."+upstream)
+	if err != nil {
+		return 0, 0, err
+	}
+	return parseAheadBehind(string(output))
+}
+
+func parseAheadBehind(output string) (int, int, error) {
+	fields := strings.Fields(output)
+	if len(fields) != 2 {
+		return 0, 0, fmt.Errorf("unexpected rev-list output %q", output)
+	}
+	ahead, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return 0, 0, err
+	}
+	behind, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return 0, 0, err
+	}
+	return ahead, behind, nil
 }
 
 func Push() error {
@@ -125,47 +254,267 @@ func PushWithBranch(defaultBranch string) error {
 	return NewGitClient("").PushWithBranch(defaultBranch)
 }
 
+// PushWithBranch pushes the current branch. When it has no upstream yet it is
+// pushed to the default push remote with --set-upstream; defaultBranch is only
+// used when the current branch name cannot be determined.
 func (g *GitClient) PushWithBranch(defaultBranch string) error {
-	mu.Lock()
-	if operationInProgress {
-		mu.Unlock()
-		return fmt.Errorf("another git operation is already in progress")
+	if err := beginOperation(); err != nil {
+		return err
 	}
-	operationInProgress = true
-	mu.Unlock()
+	defer endOperation()
 
-	defer func() {
-		mu.Lock()
-		operationInProgress = false
-		mu.Unlock()
-	}()
+	if g.HasUpstream() {
+		output, err := g.runGitCommandCombinedOutput("push")
+		if err != nil {
+			return fmt.Errorf("push failed: %v - %s", err, string(output))
+		}
+		return nil
+	}
+
+	branch := g.CurrentBranch()
+	if branch == "" {
+		branch = defaultBranch
+	}
+	if branch == "" {
+		return fmt.Errorf("push failed and could not determine current branch name")
+	}
+
+	remote := g.DefaultPushRemote(branch)
+	if remote == "" {
+		return fmt.Errorf("push failed: no remote configured")
+	}
+
+	output, err := g.runGitCommandCombinedOutput("push", "--set-upstream", remote, branch)
+	if err != nil {
+		return fmt.Errorf("push failed and could not set upstream: %v - %s", err, string(output))
+	}
+	return nil
+}
+
+// PushOptions describes a push configured in the push dialog.
+type PushOptions struct {
+	Remote         string
+	Branch         string // branch name on the remote
+	ForceWithLease bool
+	ExpectedSHA    string // remote SHA the lease expects; empty means the branch must not exist
+	Tags           bool
+	DryRun         bool
+	SetUpstream    bool
+}
 
-	output, err := g.runGitCommandCombinedOutput("push")
-	if err == nil {
+// Args returns the git push arguments for these options.
+func (o PushOptions) Args() []string {
+	args := []string{"push"}
+	if o.DryRun {
+		args = append(args, "--dry-run")
+	}
+	if o.ForceWithLease {
+		args = append(args, fmt.Sprintf("--force-with-lease=refs/heads/%s:%s", o.Branch, o.ExpectedSHA))
+	}
+	if o.Tags {
+		args = append(args, "--tags")
+	}
+	if o.SetUpstream {
+		args = append(args, "--set-upstream")
+	}
+	return append(args, o.Remote, "HEAD:refs/heads/"+o.Branch)
+}
+
+func PushWithOptions(opts PushOptions) (string, error) {
+	return NewGitClient("").PushWithOptions(opts)
+}
+
+// PushWithOptions runs git push with the given options and returns its output.
+func (g *GitClient) PushWithOptions(opts PushOptions) (string, error) {
+	if opts.Remote == "" || opts.Branch == "" {
+		return "", fmt.Errorf("push needs a remote and a branch")
+	}
+	if err := beginOperation(); err != nil {
+		return "", err
+	}
+	defer endOperation()
+
+	output, err := g.runGitCommandCombinedOutput(opts.Args()...)
+	if err != nil {
+		return "", fmt.Errorf("push failed: %v - %s", err, strings.TrimSpace(string(output)))
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+func HasUpstream() bool {
+	return NewGitClient("").HasUpstream()
+}
+
+// HasUpstream reports whether the current branch tracks a remote branch.
+// It asks rev-parse instead of parsing (possibly localized) push errors.
+func (g *GitClient) HasUpstream() bool {
+	_, err := g.runGitCommand("rev-parse", "--abbrev-ref", "--symbolic-full-name", "@{u}")
+	return err == nil
+}
+
+func CurrentBranch() string {
+	return NewGitClient("").CurrentBranch()
+}
+
+// CurrentBranch returns the checked out branch, or "" on a detached HEAD.
+func (g *GitClient) CurrentBranch() string {
+	output, err := g.runGitCommand("branch", "--show-current")
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
+
+func GetRemoteNames() []string {
+	return NewGitClient("").GetRemoteNames()
+}
+
+func (g *GitClient) GetRemoteNames() []string {
+	output, err := g.runGitCommand("remote")
+	if err != nil {
 		return nil
 	}
+	var names []string
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		if line != "" {
+			names = append(names, line)
+		}
+	}
+	return names
+}
 
-	if strings.Contains(string(output), "set the remote as upstream") ||
-		strings.Contains(string(output), "have no upstream branch") ||
-		strings.Contains(string(output), "no upstream branch") {
+func DefaultPushRemote(branch string) string {
+	return NewGitClient("").DefaultPushRemote(branch)
+}
 
-		branch := defaultBranch
-		if branch == "" {
-			branchOut, branchErr := g.runGitCommand("branch", "--show-current")
-			if branchErr != nil {
-				return fmt.Errorf("push failed and could not determine current branch: %v", branchErr)
+// DefaultPushRemote picks the remote git itself would push branch to:
+// branch.<name>.pushRemote, remote.pushDefault, branch.<name>.remote, then
+// origin or the only configured remote.
+func (g *GitClient) DefaultPushRemote(branch string) string {
+	for _, key := range []string{"branch." + branch + ".pushRemote", "remote.pushDefault", "branch." + branch + ".remote"} {
+		if output, err := g.runGitCommand("config", "--get", key); err == nil {
+			if remote := strings.TrimSpace(string(output)); remote != "" && remote != "." {
+				return remote
 			}
-			branch = strings.TrimSpace(string(branchOut))
 		}
-		if branch == "" {
-			return fmt.Errorf("push failed and could not determine current branch name")
+	}
+
+	remotes := g.GetRemoteNames()
+	for _, r := range remotes {
+		if r == "origin" {
+			return r
 		}
+	}
+	if len(remotes) > 0 {
+		return remotes[0]
+	}
+	return ""
+}
+
+func RemoteBranchSHA(remote, branch string) string {
+	return NewGitClient("").RemoteBranchSHA(remote, branch)
+}
+
+// RemoteBranchSHA returns the last fetched SHA of remote/branch, or "" when
+// the remote-tracking branch does not exist.
+func (g *GitClient) RemoteBranchSHA(remote, branch string) string {
+	output, err := g.runGitCommand("rev-parse", "--verify", "--quiet", "refs/remotes/"+remote+"/"+branch)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
 
-		output2, err2 := g.runGitCommandCombinedOutput("push", "--set-upstream", "origin", branch)
-		if err2 != nil {
-			return fmt.Errorf("push failed and could not set upstream: %v - %s", err2, string(output2))
+// RemoteBranch is a remote-tracking branch such as origin/feature.
+type RemoteBranch struct {
+	Remote string
+	Name   string
+}
+
+// Ref returns the short remote-tracking ref, e.g. "origin/feature".
+func (b RemoteBranch) Ref() string {
+	return b.Remote + "/" + b.Name
+}
+
+func GetRemoteBranches() ([]RemoteBranch, error) {
+	return NewGitClient("").GetRemoteBranches()
+}
+
+// GetRemoteBranches lists remote-tracking branches grouped by remote, skipping
+// the symbolic <remote>/HEAD refs.
+func (g *GitClient) GetRemoteBranches() ([]RemoteBranch, error) {
+	output, err := g.runGitCommand("for-each-ref", "--format=%(refname)%00%(symref)", "refs/remotes")
+	if err != nil {
+		return nil, err
+	}
+	return parseRemoteBranches(string(output)), nil
+}
+
+func parseRemoteBranches(output string) []RemoteBranch {
+	var branches []RemoteBranch
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		fields := strings.Split(line, "\x00")
+		if len(fields) < 2 || fields[1] != "" {
+			continue
 		}
-		return nil
+		ref := strings.TrimPrefix(fields[0], "refs/remotes/")
+		remote, name, ok := strings.Cut(ref, "/")
+		if !ok || name == "" || name == "HEAD" {
+			continue
+		}
+		branches = append(branches, RemoteBranch{Remote: remote, Name: name})
+	}
+	return branches
+}
+
+func CheckoutTracking(branch RemoteBranch) error {
+	return NewGitClient("").CheckoutTracking(branch)
+}
+
+// CheckoutTracking creates a local branch tracking the remote branch and switches to it.
+func (g *GitClient) CheckoutTracking(branch RemoteBranch) error {
+	if _, err := g.runGitCommand("rev-parse", "--verify", "--quiet", "refs/heads/"+branch.Name); err == nil {
+		return fmt.Errorf("a local branch named %s already exists", branch.Name)
 	}
-	return fmt.Errorf("push failed: %v - %s", err, string(output))
+	output, err := g.runGitCommandCombinedOutput("checkout", "-b", branch.Name, "--track", branch.Ref())
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+func DeleteRemoteBranch(branch RemoteBranch) error {
+	return NewGitClient("").DeleteRemoteBranch(branch)
+}
+
+// DeleteRemoteBranch deletes the branch on its remote.
+func (g *GitClient) DeleteRemoteBranch(branch RemoteBranch) error {
+	if err := beginOperation(); err != nil {
+		return err
+	}
+	defer endOperation()
+
+	output, err := g.runGitCommandCombinedOutput("push", branch.Remote, "--delete", branch.Name)
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+func FetchRemote(remote string) error {
+	return NewGitClient("").FetchRemote(remote)
+}
+
+// FetchRemote fetches a single remote, pruning branches deleted there.
+func (g *GitClient) FetchRemote(remote string) error {
+	if err := beginOperation(); err != nil {
+		return err
+	}
+	defer endOperation()
+
+	output, err := g.runGitCommandCombinedOutput("fetch", "--prune", remote)
+	if err != nil {
+		return fmt.Errorf("fetch failed: %v - %s", err, string(output))
+	}
+	return nil
 }
@@ -0,0 +1,56 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// fixtureRepo creates a repository with files tracked files, a tenth of them
+// modified, and as many untracked files as modified ones. Commits made in it
+// by the test are authored by a fixed identity.
+func fixtureRepo(tb testing.TB, files int) string {
+	tb.Helper()
+	for _, key := range []string{"GIT_AUTHOR_NAME", "GIT_COMMITTER_NAME"} {
+		tb.Setenv(key, "f")
+	}
+	for _, key := range []string{"GIT_AUTHOR_EMAIL", "GIT_COMMITTER_EMAIL"} {
+		tb.Setenv(key, "f@f")
+	}
+	root := tb.TempDir()
+	client := NewGitClient(root)
+
+	mustGit(tb, client, "init", "-q")
+	for i := 0; i < files; i++ {
+		writeFile(tb, root, fmt.Sprintf("dir%d/file%d.txt", i%100, i), "tracked\n")
+	}
+	mustGit(tb, client, "add", "-A")
+	mustGit(tb, client, "commit", "-q", "-m", "fixture")
+	for i := 0; i < files; i += 10 {
+		writeFile(tb, root, fmt.Sprintf("dir%d/file%d.txt", i%100, i), "modified\n")
+		writeFile(tb, root, fmt.Sprintf("dir%d/new%d.txt", i%100, i), "untracked\n")
+	}
+	return root
+}
+
+// mustGit runs git with args in the repository of client and fails the test
+// when it does not succeed.
+func mustGit(tb testing.TB, client *GitClient, args ...string) {
+	tb.Helper()
+	if out, err := client.runGitCommandCombinedOutput(args...); err != nil {
+		tb.Fatalf("git %v: %v\n%s", args, err, out)
+	}
+}
+
+// writeFile writes content to name under root, creating its directories.
+func writeFile(tb testing.TB, root, name, content string) {
+	tb.Helper()
+	path := filepath.Join(root, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		tb.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		tb.Fatal(err)
+	}
+}
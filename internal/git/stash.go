@@ -0,0 +1,162 @@
+package git
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// StashOptions configures git stash push.
+type StashOptions struct {
+	Message          string
+	Staged           bool     // stash only the staged changes; git takes it with none of the others
+	KeepIndex        bool     // leave the staged changes in place after stashing
+	IncludeUntracked bool     // stash untracked files too
+	Paths            []string // limit the stash to these files, all changes when empty
+}
+
+// Args returns the git arguments for the stash, without the paths, which are
+// passed on stdin.
+func (o StashOptions) Args() []string {
+	args := []string{"stash", "push", "-m", o.Message}
+	if o.Staged {
+		args = append(args, "--staged")
+	}
+	if o.KeepIndex {
+		args = append(args, "--keep-index")
+	}
+	if o.IncludeUntracked {
+		args = append(args, "--include-untracked")
+	}
+	return args
+}
+
+func StashPush(opts StashOptions) error {
+	return NewGitClient("").StashPush(opts)
+}
+
+// StashPush saves a stash as configured by opts.
+func (g *GitClient) StashPush(opts StashOptions) error {
+	var err error
+	var output []byte
+	if len(opts.Paths) > 0 {
+		output, err = g.runWithPathspecs(opts.Paths, opts.Args()...)
+	} else {
+		output, err = g.runGitCommandCombinedOutput(opts.Args()...)
+		if err != nil && len(output) > 0 {
+			err = fmt.Errorf("%w: %s", err, strings.TrimSpace(string(output)))
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to save stash: %w", err)
+	}
+	// git exits 0 when there was nothing to stash
+	if strings.Contains(string(output), "No local changes to save") {
+		return fmt.Errorf("no local changes to save")
+	}
+	return nil
+}
+
+func StashBranch(branch, ref string) error {
+	return NewGitClient("").StashBranch(branch, ref)
+}
+
+// StashBranch creates branch at the commit the stash was made on, checks it
+// out, applies the stash there and drops it once applied.
+func (g *GitClient) StashBranch(branch, ref string) error {
+	output, err := g.runGitCommandCombinedOutput("stash", "branch", branch, ref)
+	if err != nil {
+		return fmt.Errorf("failed to create branch from stash: %w: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+func StashRename(ref, message string) error {
+	return NewGitClient("").StashRename(ref, message)
+}
+
+// StashRename changes the message of a stash. git can't edit a stash entry,
+// so the stash is stored again with the new message, which puts it on top
+// as stash@{0}, and the old entry is dropped.
+func (g *GitClient) StashRename(ref, message string) error {
+	output, err := g.runGitCommand("rev-parse", ref)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", ref, err)
+	}
+	sha := strings.TrimSpace(string(output))
+
+	if _, err := g.runGitCommandCombinedOutput("stash", "store", "-m", message, sha); err != nil {
+		return fmt.Errorf("failed to store stash: %w", err)
+	}
+	// the stored entry shifted the old one down by one
+	n, err := stashIndex(ref)
+	if err != nil {
+		return err
+	}
+	if _, err := g.runGitCommandCombinedOutput("stash", "drop", fmt.Sprintf("stash@{%d}", n+1)); err != nil {
+		return fmt.Errorf("failed to drop the old stash entry: %w", err)
+	}
+	return nil
+}
+
+// stashIndex returns n of a "stash@{n}" reference.
+func stashIndex(ref string) (int, error) {
+	inner := strings.TrimSuffix(strings.TrimPrefix(ref, "stash@{"), "}")
+	n, err := strconv.Atoi(inner)
+	if err != nil {
+		return 0, fmt.Errorf("invalid stash reference %q", ref)
+	}
+	return n, nil
+}
+
+// StashInfo describes a stash entry beyond its line in git stash list.
+type StashInfo struct {
+	Ref       string // e.g. "stash@{0}"
+	Date      time.Time
+	Files     []string // tracked files the stash changes
+	Untracked bool     // untracked files were stashed too
+}
+
+const stashInfoFormat = "%x1e%gd%x00%ct%x00%P"
+
+func GetStashInfos() ([]StashInfo, error) {
+	return NewGitClient("").GetStashInfos()
+}
+
+// GetStashInfos lists the stashes with their date and the files they change.
+func (g *GitClient) GetStashInfos() ([]StashInfo, error) {
+	output, err := g.runGitCommand("stash", "list", "--name-only", "--format="+stashInfoFormat)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stashes: %w", err)
+	}
+	return parseStashInfos(string(output)), nil
+}
+
+// parseStashInfos parses stash list output in stashInfoFormat: a record per
+// stash, starting with a header line followed by the changed file names.
+func parseStashInfos(output string) []StashInfo {
+	var infos []StashInfo
+	for _, record := range strings.Split(output, "\x1e") {
+		lines := strings.Split(strings.TrimSpace(record), "\n")
+		fields := strings.Split(lines[0], "\x00")
+		if len(fields) < 3 || fields[0] == "" {
+			continue
+		}
+		info := StashInfo{
+			Ref: fields[0],
+			// a stash with untracked files has them in a third parent
+			Untracked: len(strings.Fields(fields[2])) > 2,
+		}
+		if ts, err := strconv.ParseInt(fields[1], 10, 64); err == nil {
+			info.Date = time.Unix(ts, 0)
+		}
+		for _, line := range lines[1:] {
+			if line = strings.TrimSpace(line); line != "" {
+				info.Files = append(info.Files, line)
+			}
+		}
+		infos = append(infos, info)
+	}
+	return infos
+}
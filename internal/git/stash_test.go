@@ -0,0 +1,40 @@
+package git
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStashPushAndRename(t *testing.T) {
+	client := NewGitClient(fixtureRepo(t, 20))
+
+	if err := client.StashPush(StashOptions{Message: "first", Paths: []string{"dir0/file0.txt"}}); err != nil {
+		t.Fatalf("StashPush: %v", err)
+	}
+	if err := client.StashPush(StashOptions{Message: "second", IncludeUntracked: true}); err != nil {
+		t.Fatalf("StashPush: %v", err)
+	}
+
+	infos, err := client.GetStashInfos()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(infos) != 2 || !infos[0].Untracked || infos[1].Untracked {
+		t.Fatalf("unexpected stashes %+v", infos)
+	}
+	if len(infos[1].Files) != 1 || infos[1].Files[0] != "dir0/file0.txt" {
+		t.Fatalf("expected the first stash to hold dir0/file0.txt only, got %v", infos[1].Files)
+	}
+
+	if err := client.StashRename("stash@{1}", "renamed"); err != nil {
+		t.Fatalf("StashRename: %v", err)
+	}
+	list, err := client.StashList()
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(list), "\n")
+	if len(lines) != 2 || lines[0] != "stash@{0}: renamed" || !strings.HasSuffix(lines[1], ": second") {
+		t.Fatalf("unexpected stash list %q", list)
+	}
+}
@@ -0,0 +1,100 @@
+package git
+
+import (
+	"os"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestIndexEntries(t *testing.T) {
+	client := NewGitClient(fixtureRepo(t, 30))
+	entries, err := client.IndexEntries()
+	if err != nil || entries != 30 {
+		t.Fatalf("IndexEntries() = %d, %v; want 30, nil", entries, err)
+	}
+}
+
+func TestTrackedFiles(t *testing.T) {
+	client := NewGitClient(fixtureRepo(t, 10))
+	files, err := client.TrackedFiles()
+	// the untracked new*.txt files are left out, clean files are listed
+	if err != nil || len(files) != 10 || !slices.Contains(files, "dir5/file5.txt") {
+		t.Fatalf("TrackedFiles() = %v, %v; want the 10 tracked files", files, err)
+	}
+}
+
+func TestParseGitVersion(t *testing.T) {
+	tests := []struct {
+		output       string
+		major, minor int
+		ok           bool
+	}{
+		{"git version 2.39.3 (Apple Git-146)\n", 2, 39, true},
+		{"git version 2.45.1.windows.1\n", 2, 45, true},
+		{"git version 2.35.0\n", 2, 35, true},
+		{"fatal: not a git version", 0, 0, false},
+	}
+	for _, tt := range tests {
+		major, minor, ok := parseGitVersion(tt.output)
+		if major != tt.major || minor != tt.minor || ok != tt.ok {
+			t.Errorf("parseGitVersion(%q) = %d, %d, %v; want %d, %d, %v", tt.output, major, minor, ok, tt.major, tt.minor, tt.ok)
+		}
+	}
+}
+
+// BenchmarkGetModifiedFiles reads the status of a generated repository with
+// 50000 tracked files, or FROGGIT_BENCH_FILES of them, with and without the
+// large repository options.
+func BenchmarkGetModifiedFiles(b *testing.B) {
+	files := 50000
+	if n, err := strconv.Atoi(os.Getenv("FROGGIT_BENCH_FILES")); err == nil && n > 0 {
+		files = n
+	}
+	client := NewGitClient(fixtureRepo(b, files))
+
+	for name, opts := range map[string]StatusOptions{
+		"default": {},
+		"large":   client.LoadStatusOptions("on", true),
+	} {
+		b.Run(name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := client.GetModifiedFiles(opts); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestAddAndResetPaths(t *testing.T) {
+	client := NewGitClient(fixtureRepo(t, 20))
+	paths := []string{"dir0/file0.txt", "dir0/new0.txt", "dir10/file10.txt"}
+
+	staged := func() int {
+		files, err := client.GetModifiedFiles(StatusOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		n := 0
+		for _, f := range files {
+			if f.Staged {
+				n++
+			}
+		}
+		return n
+	}
+
+	if err := client.AddPaths(paths); err != nil {
+		t.Fatalf("AddPaths: %v", err)
+	}
+	if n := staged(); n != 3 {
+		t.Fatalf("expected 3 staged files, got %d", n)
+	}
+	if err := client.ResetPaths(paths); err != nil {
+		t.Fatalf("ResetPaths: %v", err)
+	}
+	if n := staged(); n != 0 {
+		t.Fatalf("expected no staged files, got %d", n)
+	}
+}
@@ -1,6 +1,27 @@
 package git
 
-import "sync"
+import (
+	"fmt"
+	"sync"
+)
 
 var mu sync.Mutex
 var operationInProgress bool
+
+// beginOperation marks a network operation as running. It fails when another
+// one is already in progress; callers must defer endOperation on success.
+func beginOperation() error {
+	mu.Lock()
+	defer mu.Unlock()
+	if operationInProgress {
+		return fmt.Errorf("another git operation is already in progress")
+	}
+	operationInProgress = true
+	return nil
+}
+
+func endOperation() {
+	mu.Lock()
+	operationInProgress = false
+	mu.Unlock()
+}
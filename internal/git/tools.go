@@ -0,0 +1,153 @@
+package git
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// DiffRenderer describes how diffs are rendered by tools outside froggit.
+type DiffRenderer struct {
+	Pager    string // command colored diffs are piped through, e.g. "delta"
+	External bool   // diff.external is configured, so git runs it to make diffs
+}
+
+// Enabled reports whether any external tool renders diffs.
+func (r DiffRenderer) Enabled() bool {
+	return r.Pager != "" || r.External
+}
+
+func ConfigValue(key string) string {
+	return NewGitClient("").ConfigValue(key)
+}
+
+// ConfigValue returns a git config setting, or "" when it isn't set.
+func (g *GitClient) ConfigValue(key string) string {
+	output, err := g.runGitCommand("config", "--get", key)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
+
+func LoadDiffRenderer(pager string) DiffRenderer {
+	return NewGitClient("").LoadDiffRenderer(pager)
+}
+
+// LoadDiffRenderer works out the external diff renderer. pager is froggit's
+// own setting: a command to pipe diffs through, "git" to use git's pager
+// settings, or "" for froggit's built-in renderer. diff.external is always
+// honored.
+func (g *GitClient) LoadDiffRenderer(pager string) DiffRenderer {
+	r := DiffRenderer{External: g.ConfigValue("diff.external") != ""}
+	if pager != "git" {
+		r.Pager = pager
+		return r
+	}
+
+	// the order git itself looks for a pager in, with pager.diff first
+	candidates := []string{
+		g.ConfigValue("pager.diff"),
+		os.Getenv("GIT_PAGER"),
+		g.ConfigValue("core.pager"),
+		os.Getenv("PAGER"),
+	}
+	for _, candidate := range candidates {
+		if candidate == "" || candidate == "true" || candidate == "false" {
+			continue
+		}
+		// git uses the first pager set, even one that only pages
+		if !isPlainPager(candidate) {
+			r.Pager = candidate
+		}
+		return r
+	}
+	return r
+}
+
+// isPlainPager reports whether a pager only pages its input, so rendering
+// diffs through it adds nothing.
+func isPlainPager(command string) bool {
+	fields := strings.Fields(command)
+	if len(fields) == 0 {
+		return true
+	}
+	switch filepath.Base(fields[0]) {
+	case "less", "more", "most", "cat":
+		return true
+	}
+	return false
+}
+
+func RenderFileDiff(filename string, staged bool, opts DiffOptions, r DiffRenderer, width int) (string, error) {
+	return NewGitClient("").RenderFileDiff(filename, staged, opts, r, width)
+}
+
+// RenderFileDiff produces the diff of a file through the external renderer:
+// git runs diff.external if it is set, and the colored output is piped
+// through the pager. The result keeps the tools' ANSI colors.
+func (g *GitClient) RenderFileDiff(filename string, staged bool, opts DiffOptions, r DiffRenderer, width int) (string, error) {
+	args := append(opts.Args(), "--color=always")
+	if r.External {
+		args = append(args, "--ext-diff")
+	}
+	if staged {
+		args = append(args, "--cached")
+	}
+	args = append(args, "--", filename)
+
+	diffCmd := g.newCommand(args...)
+	diffCmd.Env = append(diffCmd.Env, fmt.Sprintf("COLUMNS=%d", width))
+	output, err := diffCmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to get diff for %s: %w", filename, err)
+	}
+	if r.Pager == "" || len(output) == 0 {
+		return strings.TrimRight(string(output), "\n"), nil
+	}
+
+	pagerCmd := exec.Command("sh", "-c", r.Pager)
+	pagerCmd.Dir = diffCmd.Dir
+	pagerCmd.Env = append(os.Environ(), fmt.Sprintf("COLUMNS=%d", width))
+	pagerCmd.Stdin = bytes.NewReader(output)
+	rendered, err := pagerCmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to run %s: %w", r.Pager, err)
+	}
+	return strings.TrimRight(string(rendered), "\n"), nil
+}
+
+// IsConflictStatus reports whether a porcelain status marks an unmerged file.
+func IsConflictStatus(status string) bool {
+	switch status {
+	case "UU", "AA", "DD", "AU", "UA", "DU", "UD":
+		return true
+	}
+	return false
+}
+
+func DifftoolCommand(filename string, staged bool) *exec.Cmd {
+	return NewGitClient("").DifftoolCommand(filename, staged)
+}
+
+// DifftoolCommand opens the file in the configured difftool. It needs the
+// terminal, so it is meant to be run with tea.ExecProcess.
+func (g *GitClient) DifftoolCommand(filename string, staged bool) *exec.Cmd {
+	args := []string{"difftool", "--no-prompt"}
+	if staged {
+		args = append(args, "--cached")
+	}
+	return g.newCommand(append(args, "--", filename)...)
+}
+
+func MergetoolCommand(filename string) *exec.Cmd {
+	return NewGitClient("").MergetoolCommand(filename)
+}
+
+// MergetoolCommand opens a conflicted file in the configured mergetool.
+func (g *GitClient) MergetoolCommand(filename string) *exec.Cmd {
+	return g.newCommand("mergetool", "--no-prompt", "--", filename)
+}
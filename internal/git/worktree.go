@@ -0,0 +1,192 @@
+package git
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// LargeRepoEntries is the number of tracked files from which a repository
+// counts as large, and its status is read the cheap way.
+const LargeRepoEntries = 20000
+
+func GitDir() (string, error) {
+	return NewGitClient("").GitDir()
+}
+
+// GitDir returns the absolute path of the repository's .git directory.
+func (g *GitClient) GitDir() (string, error) {
+	output, err := g.runGitCommand("rev-parse", "--absolute-git-dir")
+	if err != nil {
+		return "", fmt.Errorf("failed to find git directory: %w", err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+func WorktreeDirs() ([]string, error) {
+	return NewGitClient("").WorktreeDirs()
+}
+
+// WorktreeDirs lists the directories of the worktree that hold tracked or
+// untracked files, leaving out ignored ones. Paths are relative to the
+// repository root, which is included as ".".
+func (g *GitClient) WorktreeDirs() ([]string, error) {
+	output, err := g.runGitCommand("ls-files", "-z", "--cached", "--others", "--exclude-standard")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list worktree files: %w", err)
+	}
+
+	seen := map[string]bool{".": true}
+	dirs := []string{"."}
+	for _, file := range strings.Split(string(output), "\x00") {
+		for dir := filepath.Dir(file); file != "" && !seen[dir]; dir = filepath.Dir(dir) {
+			seen[dir] = true
+			dirs = append(dirs, dir)
+		}
+	}
+	return dirs, nil
+}
+
+func CheckIgnored(paths []string) (map[string]bool, error) {
+	return NewGitClient("").CheckIgnored(paths)
+}
+
+// CheckIgnored reports which of the paths are ignored by .gitignore and the
+// other exclude files.
+func (g *GitClient) CheckIgnored(paths []string) (map[string]bool, error) {
+	ignored := make(map[string]bool)
+	if len(paths) == 0 {
+		return ignored, nil
+	}
+
+	cmd := g.newCommand("check-ignore", "-z", "--stdin")
+	cmd.Stdin = strings.NewReader(strings.Join(paths, "\x00") + "\x00")
+	output, err := cmd.Output()
+	var exitErr *exec.ExitError
+	// check-ignore exits with 1 when none of the paths are ignored
+	if err != nil && !(errors.As(err, &exitErr) && exitErr.ExitCode() == 1) {
+		return nil, fmt.Errorf("failed to check ignored paths: %w", err)
+	}
+	for _, path := range strings.Split(string(output), "\x00") {
+		if path != "" {
+			ignored[path] = true
+		}
+	}
+	return ignored, nil
+}
+
+func StatusFingerprint() (string, error) {
+	return NewGitClient("").StatusFingerprint()
+}
+
+// StatusFingerprint summarizes the state of the worktree, the index and the
+// current branch; it changes whenever any of them does.
+func (g *GitClient) StatusFingerprint() (string, error) {
+	output, err := g.runGitCommand("status", "--porcelain=v2", "--branch", "-z")
+	if err != nil {
+		return "", fmt.Errorf("failed to get status: %w", err)
+	}
+	return string(output), nil
+}
+
+func IndexEntries() (int, error) {
+	return NewGitClient("").IndexEntries()
+}
+
+// IndexEntries returns the number of files in the index, read from its
+// header so it stays cheap however big the repository is.
+func (g *GitClient) IndexEntries() (int, error) {
+	gitDir, err := g.GitDir()
+	if err != nil {
+		return 0, err
+	}
+	f, err := os.Open(filepath.Join(gitDir, "index"))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to read index: %w", err)
+	}
+	defer f.Close()
+
+	// signature, version and entry count, each four bytes
+	header := make([]byte, 12)
+	if _, err := io.ReadFull(f, header); err != nil || string(header[:4]) != "DIRC" {
+		return 0, fmt.Errorf("failed to read index: unexpected header")
+	}
+	return int(binary.BigEndian.Uint32(header[8:])), nil
+}
+
+func LoadStatusOptions(mode string, fsmonitor bool) StatusOptions {
+	return NewGitClient("").LoadStatusOptions(mode, fsmonitor)
+}
+
+// LoadStatusOptions works out how to read the status. mode is froggit's
+// largerepo setting: "on" always reads it the cheap way, "off" never does and
+// "auto" does once the index holds LargeRepoEntries files.
+//
+// The cheap way skips untracked files and turns on git's untracked cache,
+// unless the repository configures it itself. With fsmonitor it also turns on
+// git's builtin file system monitor where git supports it; its daemon keeps
+// running in the background after froggit exits.
+func (g *GitClient) LoadStatusOptions(mode string, fsmonitor bool) StatusOptions {
+	switch mode {
+	case "off":
+		return StatusOptions{}
+	case "on":
+	default:
+		if entries, err := g.IndexEntries(); err != nil || entries < LargeRepoEntries {
+			return StatusOptions{}
+		}
+	}
+
+	return StatusOptions{
+		SkipUntracked:  true,
+		FSMonitor:      fsmonitor && g.ConfigValue("core.fsmonitor") == "" && g.fsmonitorSupported(),
+		UntrackedCache: g.ConfigValue("core.untrackedCache") == "",
+		Large:          true,
+	}
+}
+
+// fsmonitorSupported reports whether git has a builtin file system monitor:
+// it came with git 2.36 and only runs on macOS and Windows.
+func (g *GitClient) fsmonitorSupported() bool {
+	if runtime.GOOS != "darwin" && runtime.GOOS != "windows" {
+		return false
+	}
+	output, err := g.runGitCommand("version")
+	if err != nil {
+		return false
+	}
+	major, minor, ok := parseGitVersion(string(output))
+	return ok && (major > 2 || major == 2 && minor >= 36)
+}
+
+// parseGitVersion reads the major and minor version from the output of git
+// version, such as "git version 2.39.3 (Apple Git-146)".
+func parseGitVersion(output string) (major, minor int, ok bool) {
+	fields := strings.Fields(output)
+	if len(fields) < 3 || fields[0] != "git" || fields[1] != "version" {
+		return 0, 0, false
+	}
+	parts := strings.SplitN(fields[2], ".", 3)
+	if len(parts) < 2 {
+		return 0, 0, false
+	}
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, false
+	}
+	minor, err = strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, 0, false
+	}
+	return major, minor, true
+}
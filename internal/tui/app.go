@@ -12,21 +12,37 @@ import (
 type App struct {
 	M model.Model
 	C config.Config
+
+	frame string // the view rendered by the last Update
 }
 
 func (a App) Init() tea.Cmd {
+	var cmds []tea.Cmd
 	if a.M.IsFetching {
-		return async.Spinner()
+		cmds = append(cmds, async.Spinner())
+	}
+	if a.M.Askpass != nil {
+		cmds = append(cmds, async.WaitForAskpass(a.M.Askpass))
 	}
-	return nil
+	if a.M.Watcher != nil {
+		cmds = append(cmds, async.WaitForRepoChange(a.M.Watcher))
+	}
+	return tea.Batch(cmds...)
 }
 
 func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	newModel, cmd := update.Update(a.M, a.C, msg)
-	a.M = newModel
-	return a, cmd
+	newModel, refresh := update.StartRefresh(newModel)
+	newModel, detail := update.SyncDashboard(newModel)
+	a.M, a.frame = Frame(newModel, a.C)
+	return a, tea.Batch(cmd, refresh, detail)
 }
 
+// View returns the frame rendered by the last Update, so calling it again
+// keeps the mouse zones of that frame.
 func (a App) View() string {
-	return Render(a.M, a.C)
+	if a.frame == "" {
+		return Render(a.M, a.C)
+	}
+	return a.frame
 }
@@ -1,13 +1,23 @@
 package controls
 
 import (
-	"os"
 	"strings"
 
+	"froggit/internal/tui/zone"
+
 	"github.com/charmbracelet/lipgloss"
-	"golang.org/x/term"
 )
 
+// width is the terminal width control boxes wrap to, updated on resize.
+var width = 80
+
+// SetWidth sets the terminal width used to wrap the controls of every view.
+func SetWidth(w int) {
+	if w > 0 {
+		width = w
+	}
+}
+
 type Control struct {
 	Key         string
 	Description string
@@ -20,10 +30,6 @@ type ControlSet struct {
 }
 
 func NewControlSet() *ControlSet {
-	width, _, err := term.GetSize(int(os.Stdout.Fd()))
-	if err != nil || width <= 0 {
-		width = 80
-	}
 	return &ControlSet{
 		controls: []Control{},
 		width:    width,
@@ -90,7 +96,7 @@ func (cs *ControlSet) renderSimple() string {
 		} else {
 			part = keyStyle.Render(control.Key) + " " + descStyle.Render(control.Description)
 		}
-		controlParts = append(controlParts, part)
+		controlParts = append(controlParts, zone.Mark("control:"+control.Key, part))
 	}
 
 	content := strings.Join(controlParts, separatorStyle.Render(" │ "))
@@ -144,24 +150,56 @@ func (cs *ControlSet) renderSimple() string {
 	return boxStyle.Render(content)
 }
 
-func NewFileViewControls(staged bool, hasFiles bool, advancedMode bool) *ControlSet {
+// FileViewState is the file list state the file controls depend on.
+type FileViewState struct {
+	Staged          bool // some files are staged
+	HasFiles        bool
+	Advanced        bool
+	UntrackedHidden bool
+	Marked          int  // files marked for a bulk action
+	Untracked       bool // the file under the cursor is untracked
+}
+
+func NewFileViewControls(state FileViewState) *ControlSet {
 	cs := NewControlSet()
 
 	cs.Add("↑/↓", "navigate", "navigation")
+	cs.Add("/", "filter", "navigation")
+
+	if state.Marked > 0 && !state.Advanced {
+		cs.Add("space", "stage/unstage marked", "marked")
+		cs.Add("x", "discard marked", "marked")
+		cs.Add("s", "stash marked", "marked")
+		cs.Add("i", "ignore marked", "marked")
+		cs.Add("v", "mark", "marked")
+		cs.Add("V", "mark all", "marked")
+		cs.Add("esc", "clear marks", "marked")
+		return cs
+	}
 
-	if !advancedMode {
-		if hasFiles {
+	if !state.Advanced {
+		if state.HasFiles {
 			cs.Add("space", "stage/unstage", "files")
 			cs.Add("d", "diff", "files")
+			cs.Add("t", "difftool", "files")
+			cs.Add("o", "edit", "files")
 			cs.Add("x", "discard changes", "files")
+			cs.Add("v", "mark", "files")
 		}
-		if staged {
+		if state.Untracked {
+			cs.Add("i", "ignore", "files")
+		}
+		if state.Staged {
 			cs.Add("c", "commit", "files")
 		}
 		cs.Add("a", "stage all", "files")
-		if staged {
+		if state.Staged {
 			cs.Add("u", "unstage all", "files")
 		}
+		if state.UntrackedHidden {
+			cs.Add("U", "list untracked", "files")
+		}
+		cs.Add("T", "tree/list", "files")
 		cs.Add("r", "refresh", "files")
 		cs.Add("f", "fetch", "git")
 		cs.Add("l", "pull", "git")
@@ -175,6 +213,11 @@ func NewFileViewControls(staged bool, hasFiles bool, advancedMode bool) *Control
 		cs.Add("M", "merge", "advanced")
 		cs.Add("R", "rebase", "advanced")
 		cs.Add("S", "stash", "advanced")
+		cs.Add("C", "compare refs", "advanced")
+		cs.Add("B", "blame any file", "advanced")
+		cs.Add("H", "any file history", "advanced")
+		cs.Add("p", "push options", "git")
+		cs.Add("l", "pull strategy", "git")
 		cs.Add("esc", "exit advanced", "mode")
 		cs.Add("?", "help", "general")
 	}
@@ -182,12 +225,67 @@ func NewFileViewControls(staged bool, hasFiles bool, advancedMode bool) *Control
 	return cs
 }
 
+// NewDashboardControls lists the panel focus keys and the main actions of
+// the focused panel ("files", "branches", "stash" or "commits").
+func NewDashboardControls(panel string, advancedMode bool) *ControlSet {
+	cs := NewControlSet()
+	cs.Add("tab/1-4", "focus panel", "navigation")
+	cs.Add("↑/↓", "navigate", "navigation")
+
+	switch panel {
+	case "files":
+		cs.Add("/", "filter", "navigation")
+		if advancedMode {
+			cs.Add("p", "push options", "advanced")
+			cs.Add("l", "pull options", "advanced")
+			cs.Add("M", "merge", "advanced")
+			cs.Add("R", "rebase", "advanced")
+		} else {
+			cs.Add("space", "stage/unstage", "files")
+			cs.Add("d", "diff", "files")
+			cs.Add("c", "commit", "files")
+			cs.Add("a", "stage all", "files")
+			cs.Add("p", "push", "git")
+			cs.Add("l", "pull", "git")
+			cs.Add("f", "fetch", "git")
+		}
+		cs.Add("A", "advanced", "mode")
+	case "branches":
+		cs.Add("/", "filter", "navigation")
+		cs.Add("enter", "switch/checkout", "actions")
+		cs.Add("n", "new branch", "actions")
+		cs.Add("d", "delete", "actions")
+		cs.Add("r", "rename", "actions")
+	case "stash":
+		cs.Add("/", "filter", "navigation")
+		cs.Add("enter", "apply", "actions")
+		cs.Add("p", "pop", "actions")
+		cs.Add("d", "drop", "actions")
+		cs.Add("s", "save", "actions")
+	case "commits":
+		cs.Add("/", "search", "search")
+		cs.Add("s", "search commits", "search")
+	}
+
+	cs.Add("?", "help", "general")
+	cs.Add("q", "quit", "general")
+	return cs
+}
+
 func NewBranchViewControls() *ControlSet {
 	cs := NewControlSet()
 	cs.Add("↑/↓", "navigate", "navigation")
-	cs.Add("enter", "switch branch", "actions")
+	cs.Add("/", "filter", "navigation")
+	cs.Add("enter", "switch/checkout", "actions")
 	cs.Add("n", "new branch", "actions")
 	cs.Add("d", "delete branch", "actions")
+	cs.Add("D", "force delete", "actions")
+	cs.Add("r", "rename", "actions")
+	cs.Add("C", "clean up", "actions")
+	cs.Add("c", "compare with current", "actions")
+	cs.Add("u", "set upstream", "tracking")
+	cs.Add("U", "unset upstream", "tracking")
+	cs.Add("F", "fetch remote", "git")
 	cs.Add("esc", "back", "navigation")
 	cs.Add("q", "quit", "general")
 	return cs
@@ -214,8 +312,14 @@ func NewCommitViewControls(copilotAvailable bool, isGenerating bool) *ControlSet
 	return cs
 }
 
-func NewMergeViewControls(hasSelection bool) *ControlSet {
+func NewMergeViewControls(hasSelection, hasConflicts bool) *ControlSet {
 	cs := NewControlSet()
+	if hasConflicts {
+		cs.Add("↑/↓", "select conflict", "navigation")
+		cs.Add("o", "edit", "actions")
+		cs.Add("esc", "cancel", "navigation")
+		return cs
+	}
 	cs.Add("↑/↓", "navigate", "navigation")
 	cs.Add("enter", "select branch", "actions")
 	if hasSelection {
@@ -225,8 +329,14 @@ func NewMergeViewControls(hasSelection bool) *ControlSet {
 	return cs
 }
 
-func NewRebaseViewControls(hasSelection bool) *ControlSet {
+func NewRebaseViewControls(hasSelection, hasConflicts bool) *ControlSet {
 	cs := NewControlSet()
+	if hasConflicts {
+		cs.Add("↑/↓", "select conflict", "navigation")
+		cs.Add("o", "edit", "actions")
+		cs.Add("esc", "cancel", "navigation")
+		return cs
+	}
 	cs.Add("↑/↓", "navigate", "navigation")
 	cs.Add("enter", "select branch", "actions")
 	if hasSelection {
@@ -241,10 +351,13 @@ func NewStashViewControls(hasChanges bool, hasStashes bool) *ControlSet {
 
 	if hasStashes {
 		cs.Add("↑/↓", "navigate", "navigation")
+		cs.Add("/", "filter", "navigation")
 		cs.Add("enter", "apply stash", "actions")
 		cs.Add("p", "pop stash", "actions")
 		cs.Add("d", "drop stash", "actions")
 		cs.Add("v", "view stash", "actions")
+		cs.Add("b", "branch from stash", "actions")
+		cs.Add("r", "rename stash", "actions")
 	}
 
 	if hasChanges {
@@ -259,11 +372,94 @@ func NewStashViewControls(hasChanges bool, hasStashes bool) *ControlSet {
 func NewStashMessageViewControls() *ControlSet {
 	cs := NewControlSet()
 	cs.Add("enter", "save stash", "actions")
+	cs.Add("tab", "next option", "navigation")
+	cs.Add("space", "toggle option", "actions")
 	cs.Add("backspace", "delete char", "edit")
 	cs.Add("esc", "cancel", "navigation")
 	return cs
 }
 
+func NewStashInputViewControls(action string) *ControlSet {
+	cs := NewControlSet()
+	if action == "branch" {
+		cs.Add("enter", "create branch", "actions")
+	} else {
+		cs.Add("enter", "rename", "actions")
+	}
+	cs.Add("backspace", "delete char", "edit")
+	cs.Add("esc", "cancel", "navigation")
+	return cs
+}
+
+func NewBlameViewControls(steppedBack bool) *ControlSet {
+	cs := NewControlSet()
+	cs.Add("↑/↓", "navigate", "navigation")
+	cs.Add("enter", "show commit", "actions")
+	cs.Add("p", "blame previous revision", "actions")
+	cs.Add("H", "file history", "actions")
+	if steppedBack {
+		cs.Add("esc", "step forward", "navigation")
+	} else {
+		cs.Add("o", "edit at line", "actions")
+		cs.Add("esc", "back", "navigation")
+	}
+	return cs
+}
+
+func NewHistoryViewControls() *ControlSet {
+	cs := NewControlSet()
+	cs.Add("↑/↓", "navigate", "navigation")
+	cs.Add("enter", "show diff", "actions")
+	cs.Add("r", "restore file", "actions")
+	cs.Add("c", "checkout file", "actions")
+	cs.Add("esc", "back", "navigation")
+	return cs
+}
+
+func NewFilePickViewControls() *ControlSet {
+	cs := NewControlSet()
+	cs.Add("↑/↓", "select file", "navigation")
+	cs.Add("enter", "open", "actions")
+	cs.Add("backspace", "delete char", "edit")
+	cs.Add("esc", "back", "navigation")
+	return cs
+}
+
+func NewCompareViewControls(picking bool) *ControlSet {
+	cs := NewControlSet()
+	if picking {
+		cs.Add("↑/↓", "select ref", "navigation")
+		cs.Add("tab", "switch ref", "navigation")
+		cs.Add("enter", "pick/compare", "actions")
+		cs.Add("backspace", "delete char", "edit")
+		cs.Add("esc", "back", "navigation")
+		return cs
+	}
+	cs.Add("↑/↓", "navigate", "navigation")
+	cs.Add("enter", "show commit/file diff", "actions")
+	cs.Add("d", "diff all files", "actions")
+	cs.Add("s", "swap refs", "actions")
+	cs.Add("esc", "change refs", "navigation")
+	return cs
+}
+
+func NewIgnoreViewControls() *ControlSet {
+	cs := NewControlSet()
+	cs.Add("↑/↓", "switch field", "navigation")
+	cs.Add("←/→", "change", "actions")
+	cs.Add("enter", "ignore", "actions")
+	cs.Add("I", "why ignored", "actions")
+	cs.Add("esc", "cancel", "navigation")
+	return cs
+}
+
+func NewIgnoreCheckViewControls() *ControlSet {
+	cs := NewControlSet()
+	cs.Add("backspace", "delete char", "edit")
+	cs.Add("esc", "back", "navigation")
+	return cs
+}
+
 func NewNewBranchViewControls() *ControlSet {
 	cs := NewControlSet()
 	cs.Add("enter", "create branch", "actions")
@@ -299,6 +495,7 @@ func NewConfirmDialogControls() *ControlSet {
 func NewRepositoryListControls() *ControlSet {
 	cs := NewControlSet()
 	cs.Add("↑/↓", "navigate", "navigation")
+	cs.Add("/", "filter", "navigation")
 	cs.Add("c", "clone repository", "actions")
 	cs.Add("esc", "back", "navigation")
 	return cs
@@ -321,16 +518,139 @@ func NewHelpViewControls() *ControlSet {
 	return cs
 }
 
-func NewDiffViewControls() *ControlSet {
+// DiffViewState is the diff display state the diff controls depend on.
+type DiffViewState struct {
+	Split            bool
+	IgnoreWhitespace bool
+	Highlight        bool
+	External         bool // rendered by an external tool
+	HasRenderer      bool // an external renderer is configured
+	Revision         bool // the diff of a past commit rather than of the worktree
+}
+
+func NewDiffViewControls(state DiffViewState) *ControlSet {
 	cs := NewControlSet()
 	cs.Add("↑/↓", "scroll", "navigation")
+	cs.Add("/", "search", "search")
+	cs.Add("n/N", "next/prev match", "search")
+	if !state.External {
+		if state.Split {
+			cs.Add("v", "unified", "diff")
+		} else {
+			cs.Add("v", "side by side", "diff")
+		}
+	}
+	if state.IgnoreWhitespace {
+		cs.Add("w", "show whitespace", "diff")
+	} else {
+		cs.Add("w", "ignore whitespace", "diff")
+	}
+	cs.Add("+/-", "context", "diff")
+	if !state.External {
+		if state.Highlight {
+			cs.Add("h", "plain", "diff")
+		} else {
+			cs.Add("h", "highlight", "diff")
+		}
+	}
+	if state.Revision {
+		cs.Add("esc", "back", "navigation")
+		return cs
+	}
+	if state.External {
+		cs.Add("e", "built-in view", "diff")
+	} else if state.HasRenderer {
+		cs.Add("e", "external view", "diff")
+	}
+	cs.Add("t", "difftool", "tools")
+	cs.Add("o", "edit", "tools")
+	cs.Add("H", "history", "tools")
 	cs.Add("esc", "back", "navigation")
 	return cs
 }
 
-func NewLogGraphViewControls() *ControlSet {
+func NewLogGraphViewControls(searchResults bool) *ControlSet {
 	cs := NewControlSet()
 	cs.Add("↑/↓", "navigate", "navigation")
+	cs.Add("/", "filter", "search")
+	cs.Add("f", "find", "search")
+	cs.Add("n/N", "next/prev match", "search")
+	cs.Add("s", "search commits", "search")
+	if searchResults {
+		cs.Add("esc", "full log", "navigation")
+	} else {
+		cs.Add("esc", "back", "navigation")
+	}
+	return cs
+}
+func NewAskpassViewControls() *ControlSet {
+	cs := NewControlSet()
+	cs.Add("enter", "submit", "actions")
+	cs.Add("backspace", "delete char", "edit")
+	cs.Add("esc", "cancel", "navigation")
+	return cs
+}
+
+func NewLogSearchViewControls() *ControlSet {
+	cs := NewControlSet()
+	cs.Add("tab", "search kind", "actions")
+	cs.Add("enter", "search", "actions")
+	cs.Add("backspace", "delete char", "edit")
+	cs.Add("esc", "cancel", "navigation")
+	return cs
+}
+
+func NewPushViewControls() *ControlSet {
+	cs := NewControlSet()
+	cs.Add("↑/↓", "field", "navigation")
+	cs.Add("←/→", "remote", "actions")
+	cs.Add("space", "toggle", "actions")
+	cs.Add("enter", "push", "actions")
+	cs.Add("esc", "cancel", "navigation")
+	return cs
+}
+
+func NewPullViewControls() *ControlSet {
+	cs := NewControlSet()
+	cs.Add("↑/↓", "navigate", "navigation")
+	cs.Add("enter", "pull", "actions")
+	cs.Add("esc", "cancel", "navigation")
+	return cs
+}
+
+func NewSetUpstreamViewControls() *ControlSet {
+	cs := NewControlSet()
+	cs.Add("enter", "set upstream", "actions")
+	cs.Add("backspace", "delete char", "edit")
+	cs.Add("esc", "cancel", "navigation")
+	return cs
+}
+
+func NewTypedConfirmControls() *ControlSet {
+	cs := NewControlSet()
+	cs.Add("enter", "confirm", "actions")
+	cs.Add("backspace", "delete char", "edit")
+	cs.Add("esc", "cancel", "navigation")
+	return cs
+}
+
+func NewRenameBranchViewControls(canRenameRemote bool) *ControlSet {
+	cs := NewControlSet()
+	cs.Add("enter", "rename", "actions")
+	if canRenameRemote {
+		cs.Add("tab", "toggle remote", "actions")
+	}
+	cs.Add("backspace", "delete char", "edit")
+	cs.Add("esc", "cancel", "navigation")
+	return cs
+}
+
+func NewCleanupViewControls() *ControlSet {
+	cs := NewControlSet()
+	cs.Add("↑/↓", "navigate", "navigation")
+	cs.Add("space", "toggle", "actions")
+	cs.Add("a", "toggle all", "actions")
+	cs.Add("enter", "delete selected", "actions")
 	cs.Add("esc", "back", "navigation")
 	return cs
-}
\ No newline at end of file
+}
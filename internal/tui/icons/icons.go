@@ -5,59 +5,65 @@ import (
 	"strings"
 )
 
-func GetIconForFile(name string) string {
+// fileTypes maps file extensions to their icon and the lexer used to
+// highlight their syntax, which is empty for files that aren't code.
+var fileTypes = []struct {
+	exts     []string
+	icon     string
+	language string
+}{
+	{[]string{".go"}, "", "go"},                                          // Go
+	{[]string{".js"}, "", "javascript"},                                  // JavaScript
+	{[]string{".ts"}, "", "typescript"},                                  // TypeScript
+	{[]string{".jsx"}, "", "react"},                                      // React JSX
+	{[]string{".tsx"}, "", "typescript"},                                 // React TSX
+	{[]string{".py"}, "", "python"},                                      // Python
+	{[]string{".java"}, "", "java"},                                      // Java
+	{[]string{".rb"}, "", "ruby"},                                        // Ruby
+	{[]string{".php"}, "", "php"},                                        // PHP
+	{[]string{".html", ".htm"}, "", "html"},                              // HTML
+	{[]string{".css"}, "", "css"},                                        // CSS
+	{[]string{".json"}, "", "json"},                                      // JSON
+	{[]string{".md"}, "", "markdown"},                                    // Markdown
+	{[]string{".sh"}, "", "bash"},                                        // Shell
+	{[]string{".yml", ".yaml"}, "", "yaml"},                              // YAML
+	{[]string{".rs"}, "", "rust"},                                        // Rust
+	{[]string{".cpp", ".cc", ".cxx", ".c++", ".h", ".hpp"}, "", "c++"},   // C/C++
+	{[]string{".txt"}, "", ""},                                           // Texto plano
+	{[]string{".lock"}, "", ""},                                          // Archivo de lock
+	{[]string{".env"}, "", ""},                                           // Archivo de entorno
+	{[]string{".svg", ".png", ".jpg", ".jpeg", ".gif", ".webp"}, "", ""}, // Imágenes
+	{[]string{".exe"}, "", ""},                                           // Ejecutable Windows
+	{[]string{".zip", ".tar", ".gz", ".rar"}, "", ""},                    // Archivo comprimido
+	{[]string{".log"}, "", ""},                                           // Log
+}
+
+// defaultIcon is shown for files with an unknown extension.
+const defaultIcon = "" // Archivo genérico
+
+// lookup finds the file type of name by its extension.
+func lookup(name string) (icon, language string, ok bool) {
 	ext := strings.ToLower(filepath.Ext(name))
+	for _, t := range fileTypes {
+		for _, e := range t.exts {
+			if e == ext {
+				return t.icon, t.language, true
+			}
+		}
+	}
+	return "", "", false
+}
 
-	switch ext {
-	case ".go":
-		return "" // Go
-	case ".js":
-		return "" // JavaScript
-	case ".ts":
-		return "" // TypeScript
-	case ".jsx":
-		return "" // React JSX
-	case ".tsx":
-		return "" // React TSX
-	case ".py":
-		return "" // Python
-	case ".java":
-		return "" // Java
-	case ".rb":
-		return "" // Ruby
-	case ".php":
-		return "" // PHP
-	case ".html", ".htm":
-		return "" // HTML
-	case ".css":
-		return "" // CSS
-	case ".json":
-		return "" // JSON
-	case ".md":
-		return "" // Markdown
-	case ".sh":
-		return "" // Shell
-	case ".yml", ".yaml":
-		return "" // YAML
-	case ".rs":
-		return "" // Rust
-	case ".cpp", ".cc", ".cxx", ".c++", ".h", ".hpp":
-		return "" // C/C++
-	case ".txt":
-		return "" // Texto plano
-	case ".lock":
-		return "" // Archivo de lock
-	case ".env":
-		return "" // Archivo de entorno
-	case ".svg", ".png", ".jpg", ".jpeg", ".gif", ".webp":
-		return "" // Imágenes
-	case ".exe":
-		return "" // Ejecutable Windows
-	case ".zip", ".tar", ".gz", ".rar":
-		return "" // Archivo comprimido
-	case ".log":
-		return "" // Log
-	default:
-		return "" // Archivo genérico
+func GetIconForFile(name string) string {
+	if icon, _, ok := lookup(name); ok {
+		return icon
 	}
+	return defaultIcon
+}
+
+// GetLanguageForFile returns the syntax highlighting lexer for a file, or ""
+// when files of its kind aren't highlighted.
+func GetLanguageForFile(name string) string {
+	_, language, _ := lookup(name)
+	return language
 }
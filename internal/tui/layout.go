@@ -0,0 +1,44 @@
+package tui
+
+import (
+	"strings"
+
+	"froggit/internal/config"
+	"froggit/internal/tui/controls"
+	"froggit/internal/tui/model"
+	view "froggit/internal/tui/views"
+	"froggit/internal/tui/zone"
+)
+
+// Frame sizes the current view to the terminal and renders it. The title and
+// messages drawn around the view are rendered once and measured, so views can
+// give the remaining rows to their scrollable regions; the file cursor is kept
+// inside its viewport, which is why the laid out model comes back too. The
+// mouse zones of the frame are recorded and their markers stripped.
+func Frame(m model.Model, cfg config.Config) (model.Model, string) {
+	header, footer := renderHeader(m, cfg), renderFooter(m)
+	if m.Height > 0 {
+		controls.SetWidth(m.Width)
+		chrome := strings.Count(header, "\n") + strings.Count(footer, "\n")
+		m.ContentHeight = max(1, m.Height-chrome)
+	}
+
+	var body string
+	if _, isPanel := model.PanelOf(m.CurrentView); m.Dashboard && isPanel {
+		body = view.RenderDashboard(m)
+	} else if m.CurrentView == model.FileView {
+		m, body = view.LayoutFileView(m)
+	} else {
+		body = renderView(m)
+	}
+
+	content := header + body + footer
+	switch strings.ToLower(cfg.Ui.Position) {
+	case "center":
+		content = renderCentered(m, content)
+	case "right":
+		content = renderRight(m, content)
+	}
+	// the zones of this frame replace those of the last one, once
+	return m, zone.Scan(content)
+}
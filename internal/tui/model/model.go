@@ -1,10 +1,15 @@
 package model
 
 import (
+	"froggit/internal/askpass"
 	"froggit/internal/copilot"
 	"froggit/internal/gh"
 	"froggit/internal/git"
+	"froggit/internal/tui/syntax"
+	"froggit/internal/watcher"
 	"strings"
+	"sync"
+	"time"
 )
 
 type View int
@@ -28,11 +33,101 @@ const (
 	StashView
 	StashMessageView
 	DiffView
+	AskpassView
+	PushView
+	PullView
+	SetUpstreamView
+	RenameBranchView
+	CleanupView
+	LogSearchView
+	StashInputView
+	IgnoreView
+	IgnoreCheckView
+	BlameView
+	HistoryView
+	CompareView
+	FilePickView
 )
 
+// Panels of the dashboard layout, in focus order.
+const (
+	PanelFiles = iota
+	PanelBranches
+	PanelStash
+	PanelCommits
+	PanelCount
+)
+
+// PanelView returns the view that handles keys while panel is focused.
+func PanelView(panel int) View {
+	switch panel {
+	case PanelBranches:
+		return BranchView
+	case PanelStash:
+		return StashView
+	case PanelCommits:
+		return LogGraphView
+	default:
+		return FileView
+	}
+}
+
+// PanelOf returns the dashboard panel shown by view, if any.
+func PanelOf(view View) (int, bool) {
+	for panel := 0; panel < PanelCount; panel++ {
+		if PanelView(panel) == view {
+			return panel, true
+		}
+	}
+	return 0, false
+}
+
+// Fields of the push dialog, in display order.
+const (
+	PushFieldRemote = iota
+	PushFieldBranch
+	PushFieldForce
+	PushFieldTags
+	PushFieldDryRun
+	PushFieldCount
+)
+
+// Fields of the save stash dialog, in display order.
+const (
+	StashFieldMessage = iota
+	StashFieldStaged
+	StashFieldKeepIndex
+	StashFieldUntracked
+	StashFieldCount
+)
+
+// Fields of the ignore dialog, in display order.
+const (
+	IgnoreFieldPattern = iota
+	IgnoreFieldFile
+	IgnoreFieldCount
+)
+
+// Refs picked in the compare view, in display order.
+const (
+	CompareFieldBase = iota
+	CompareFieldTarget
+	CompareFieldCount
+)
+
+// BlameFrame is a revision of a file shown in BlameView.
+type BlameFrame struct {
+	Path   string
+	Rev    string // "" for the worktree
+	Cursor int
+}
+
 type Model struct {
 	Files            []git.FileItem
+	StatusOptions    git.StatusOptions // how Files are read, e.g. without untracked files
 	Branches         []string
+	BranchInfos      []git.BranchInfo // tracking details, same order as Branches
+	RemoteBranches   []git.RemoteBranch
 	Remotes          []string
 	CurrentBranch    string
 	Cursor           int
@@ -54,7 +149,8 @@ type Model struct {
 	ShowHelpPanel    bool
 	AdvancedMode     bool
 
-	LogLines []string
+	LogLines       []string
+	ConflictCursor int // conflicted file selected in MergeView and RebaseView, listed in LogLines
 
 	Repositories      []gh.Repository
 	SelectedRepoIndex int
@@ -68,16 +164,88 @@ type Model struct {
 	MergeStep           string // "select", "confirm", "conflict"
 	RebaseStep          string // "select", "confirm", "conflict"
 
-	Stashes       []string
-	StashMessage  string
-	SelectedStash int
-	IsStashing    bool
+	Stashes        []string
+	StashInfos     []git.StashInfo // date and files of each stash, same order as Stashes
+	StashMessage   string
+	StashPaths     []string // files the stash saved in StashMessageView is limited to
+	StashField     int      // focused field of StashMessageView
+	StashStaged    bool
+	StashKeepIndex bool
+	StashUntracked bool
+	StashRef       string // stash renamed or turned into a branch in StashInputView
+	StashAction    string // "rename" or "branch"
+	StashInput     string
+	SelectedStash  int
+	IsStashing     bool
+
+	IgnorePath    string             // untracked path IgnoreView adds a pattern for
+	IgnoreTargets []git.IgnoreTarget // files the pattern can be written to
+	IgnoreTarget  int
+	IgnorePattern int // selected entry of IgnorePatterns
+	IgnoreField   int
+	IgnoreCheck   string          // path typed in IgnoreCheckView
+	IgnoreMatch   git.IgnoreMatch // why IgnoreCheck is ignored or not
+
+	Blame       BlameFrame // file and revision shown in BlameView
+	BlameLines  []git.BlameLine
+	BlameCursor int
+	BlameStack  []BlameFrame // later revisions stepped back from, restored by esc
+
+	HistoryPath    string // file whose commits HistoryView lists
+	HistoryCommits []git.FileCommit
+	HistoryCursor  int
+	HistoryBack    View // view esc in HistoryView returns to
+
+	FilePickList   []string // tracked files FilePickView offers
+	FilePickInput  string   // part of the path typed in FilePickView
+	FilePickCursor int      // selected entry of FilePickMatches
+	FilePickFor    View     // BlameView or HistoryView, opened on the picked file
+
+	CompareRefs      [CompareFieldCount]string // base and target refs of CompareView
+	CompareField     int                       // ref being picked
+	CompareRefList   []string                  // branches and tags the pickers offer
+	CompareRefCursor int                       // selected entry of CompareRefMatches
+	ComparePicking   bool                      // the refs are being picked rather than compared
+	CompareAhead     []git.CommitInfo          // commits of the target missing from the base
+	CompareBehind    []git.CommitInfo          // commits of the base missing from the target
+	CompareFiles     []git.CompareFile         // files the target changed since it forked
+	CompareCursor    int                       // row among the commits and files
 
 	FileViewOffset int
-	FileViewHeight int
+	FileViewHeight int             // rows of the file list, sized to the terminal
+	FileTree       bool            // show the files as a directory tree
+	CollapsedDirs  map[string]bool // directories of the tree whose files are hidden
+	TreeDir        string          // directory selected in the tree, "" when a file is
+
+	Dashboard        bool            // side-by-side panels instead of one view at a time
+	Panel            int             // focused dashboard panel
+	PanelCursors     [PanelCount]int // cursor of each panel while another one is focused
+	RecentCommits    []string        // log graph shown in the commits panel
+	DetailKey        string          // selection DetailLines were loaded for
+	DetailTitle      string
+	DetailLines      []string // diff or details of the selection, shown in the main panel
+	DetailHighlights [][]syntax.Span
+
+	Width         int // terminal size from the last tea.WindowSizeMsg
+	Height        int
+	ContentHeight int // rows left for the current view between the title and the messages
+
+	LastClickID string // zone of the last left click, to detect double clicks
+	LastClickAt time.Time
 
 	DiffLines      []string
 	DiffViewOffset int
+	DiffOptions    git.DiffOptions  // whitespace and context settings of file diffs
+	DiffSplit      bool             // show diffs side by side instead of unified
+	DiffPlain      bool             // syntax highlighting of diffs turned off
+	DiffHighlights [][]syntax.Span  // highlighted code of each diff line
+	DiffRenderer   git.DiffRenderer // external tools diffs can be rendered through
+	DiffBuiltin    bool             // render diffs in froggit despite DiffRenderer
+	DiffRev        string           // commit whose change DiffView shows, "" for the file under the cursor
+	DiffBase       string           // with DiffRev, show what DiffRev changed since it forked from DiffBase
+	DiffPaths      []string         // files the diff of DiffRev is limited to
+	DiffTitle      string
+	DiffBack       View // view esc in DiffView returns to when DiffRev is set
 
 	IsGeneratingAI   bool
 	CopilotAvailable bool
@@ -88,18 +256,75 @@ type Model struct {
 	MessageID    int
 	AwaitingPush bool
 
+	PushRemotes   []string // remote names offered in PushView
+	PushRemote    string
+	PushBranch    string
+	PushRemoteSHA string // last fetched SHA of PushRemote/PushBranch
+	PushForce     bool
+	PushTags      bool
+	PushDryRun    bool
+	PushField     int
+
+	PullCursor int // selected strategy in PullView
+	PullAhead  int
+	PullBehind int
+
+	UpstreamBranch string // branch whose upstream is edited in SetUpstreamView
+	UpstreamInput  string
+
+	RenameFrom     string // branch being renamed in RenameBranchView
+	RenameInput    string
+	RenameOnRemote bool
+
+	DeletingRemote git.RemoteBranch // remote branch the delete_remote_branch dialog is for
+
+	Filter       string // fuzzy filter typed after "/" in list views
+	FilterView   View   // view the filter belongs to
+	FilterTyping bool
+
+	Search       string // regex typed after "/" in DiffView and LogGraphView
+	SearchView   View   // view the search belongs to
+	SearchTyping bool
+	SearchOrigin int // position the incremental search started from
+	SearchMatch  int // line of the current match, -1 when there is none
+
+	LogSearchKind  git.LogSearchKind
+	LogSearchInput string
+	LogSearchTitle string // describes the search while LogLines hold its results
+	LogBack        View   // view esc in LogGraphView returns to
+
+	ConfirmInput      string // typed confirmation for destructive dialogs
+	CleanupBase       string // branch merged branches are compared against
+	CleanupCandidates []git.CleanupCandidate
+	CleanupForce      []string // unmerged cleanup branches awaiting their typed force delete, the first one shown
+
 	QuickStartOptions []string
 	HasGitHubCLI      bool
+
+	Askpass           *askpass.Server  // relays credential prompts from git/ssh
+	AskpassRequest    *askpass.Request // prompt currently shown in AskpassView
+	AskpassInput      string
+	AskpassReturnView View
+
+	Watcher *watcher.Watcher // reports changes made outside froggit, nil when off
+
+	RefreshQueued  RefreshScope // parts waiting for a background load
+	RefreshRunning RefreshScope // parts the running background load fetches
+	RefreshStale   RefreshScope // parts requested again since that load started
 }
 
-func InitialModel() Model {
-	files, _ := git.GetModifiedFiles()
-	branches, current := git.GetBranches()
+func InitialModel(status git.StatusOptions) Model {
+	files, _ := git.GetModifiedFiles(status)
+	infos, branches, current := loadBranches()
+	remoteBranches, _ := git.GetRemoteBranches()
 	remotes, _ := git.GetRemotes()
 
 	return Model{
 		Files:            files,
+		StatusOptions:    status,
 		Branches:         branches,
+		BranchInfos:      infos,
+		RemoteBranches:   remoteBranches,
 		Remotes:          remotes,
 		CurrentBranch:    current,
 		Cursor:           0,
@@ -133,47 +358,352 @@ func InitialModel() Model {
 	}
 }
 
-func (m *Model) RefreshData() {
-	filesCh := make(chan []git.FileItem)
-	branchesCh := make(chan []string)
-	currentCh := make(chan string)
-	remotesCh := make(chan []string)
-	hasRemoteChangesCh := make(chan bool)
+// RefreshScope selects the parts of the repository state a refresh loads, so
+// an action that only touches one area doesn't rerun every git command.
+type RefreshScope int
+
+const (
+	RefreshFiles    RefreshScope = 1 << iota // changed files
+	RefreshBranches                          // branches, remotes and remote changes
+	RefreshStashes
+	RefreshCommits // the dashboard commits panel
+
+	RefreshAll = RefreshFiles | RefreshBranches | RefreshStashes | RefreshCommits
+	// RefreshHead is what moving HEAD changes: a checkout, merge, rebase or pull
+	RefreshHead = RefreshFiles | RefreshBranches | RefreshCommits
+)
+
+// RepoData is the repository state froggit shows, loaded by LoadRepoData.
+// Only the parts in Scope are set.
+type RepoData struct {
+	Scope            RefreshScope
+	Files            []git.FileItem
+	Branches         []string
+	BranchInfos      []git.BranchInfo
+	Remotes          []string
+	RemoteBranches   []git.RemoteBranch
+	CurrentBranch    string
+	HasRemoteChanges bool
+	Stashes          []string
+	StashInfos       []git.StashInfo
+	RecentCommits    []string // only loaded for the dashboard
+}
+
+// LoadRepoData runs the git commands that gather the parts of the repository
+// state in scope, in parallel. It doesn't touch the model, so it can run in
+// the background.
+func LoadRepoData(scope RefreshScope, dashboard bool, status git.StatusOptions) RepoData {
+	if !dashboard {
+		scope &^= RefreshCommits
+	}
+	d := RepoData{Scope: scope}
+	var wg sync.WaitGroup
+	load := func(part RefreshScope, fn func()) {
+		if scope&part == 0 {
+			return
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			fn()
+		}()
+	}
+
+	load(RefreshFiles, func() {
+		d.Files, _ = git.GetModifiedFiles(status)
+	})
+	load(RefreshBranches, func() {
+		d.BranchInfos, d.Branches, d.CurrentBranch = loadBranches()
+		d.HasRemoteChanges, _ = git.HasRemoteChangesWithFetch(d.CurrentBranch, false)
+	})
+	load(RefreshBranches, func() {
+		d.Remotes, _ = git.GetRemotes()
+	})
+	load(RefreshBranches, func() {
+		d.RemoteBranches, _ = git.GetRemoteBranches()
+	})
+	load(RefreshStashes, func() {
+		d.Stashes, d.StashInfos = loadStashes()
+	})
+	load(RefreshCommits, func() {
+		d.RecentCommits = loadRecentCommits()
+	})
+	wg.Wait()
+	return d
+}
+
+// ApplyRepoData shows freshly loaded repository state. The cursor stays on
+// the same file or branch when it is still there, so a refresh doesn't move
+// the selection when entries appear or disappear above it.
+func (m *Model) ApplyRepoData(d RepoData) {
+	selectedFile := ""
+	if m.Cursor < len(m.Files) {
+		selectedFile = m.Files[m.Cursor].Name
+	}
+	selectedBranch := ""
+	if m.Cursor < len(m.Branches) {
+		selectedBranch = m.Branches[m.Cursor]
+	}
+
+	if d.Scope&RefreshFiles != 0 {
+		m.SetFiles(d.Files)
+	}
+	if d.Scope&RefreshBranches != 0 {
+		m.Branches = d.Branches
+		m.BranchInfos = d.BranchInfos
+		m.Remotes = d.Remotes
+		m.RemoteBranches = d.RemoteBranches
+		m.CurrentBranch = d.CurrentBranch
+		m.HasRemoteChanges = d.HasRemoteChanges
+	}
+	if d.Scope&RefreshStashes != 0 {
+		m.Stashes = d.Stashes
+		m.StashInfos = d.StashInfos
+	}
+
+	switch {
+	case m.CurrentView == FileView && d.Scope&RefreshFiles != 0:
+		for i, file := range m.Files {
+			if file.Name == selectedFile {
+				m.Cursor = i
+				break
+			}
+		}
+		m.Cursor = max(0, min(m.Cursor, len(m.Files)-1))
+		if m.TreeDir != "" && !m.HasFilesIn(m.TreeDir) {
+			m.TreeDir = ""
+		}
+	case m.CurrentView == BranchView && d.Scope&RefreshBranches != 0:
+		for i, branch := range m.Branches {
+			if branch == selectedBranch {
+				m.Cursor = i
+				break
+			}
+		}
+		m.Cursor = max(0, min(m.Cursor, m.BranchRowCount()-1))
+	case m.CurrentView == StashView && d.Scope&RefreshStashes != 0:
+		m.Cursor = max(0, min(m.Cursor, len(m.Stashes)-1))
+	}
+
+	if m.Dashboard {
+		if d.Scope&RefreshCommits != 0 {
+			m.RecentCommits = d.RecentCommits
+		}
+		// forget the details so the main panel reloads them
+		m.DetailKey = ""
+	}
+}
+
+// Refresh asks for the parts of the repository state in scope to be loaded
+// again. The loading happens in the background, started by the update loop
+// through StartRefresh; requests made meanwhile are merged into one.
+func (m *Model) Refresh(scope RefreshScope) {
+	m.RefreshQueued |= scope
+	m.RefreshStale |= scope
+}
+
+// StartRefresh takes the queued parts of the state for a background load. It
+// returns false while a load is still running or when nothing is queued.
+func (m *Model) StartRefresh() (RefreshScope, bool) {
+	if m.RefreshRunning != 0 || m.RefreshQueued == 0 {
+		return 0, false
+	}
+	m.RefreshRunning = m.RefreshQueued
+	m.RefreshQueued = 0
+	m.RefreshStale = 0
+	return m.RefreshRunning, true
+}
+
+// FinishRefresh applies the result of a background load. Parts that were
+// requested again while it ran are stale and dropped; the queued request
+// loads them anew.
+func (m *Model) FinishRefresh(d RepoData) {
+	d.Scope &^= m.RefreshStale
+	m.RefreshRunning = 0
+	m.RefreshStale = 0
+	if d.Scope != 0 {
+		m.ApplyRepoData(d)
+	}
+}
+
+// SetFiles replaces the changed files, keeping the marks of those still
+// listed.
+func (m *Model) SetFiles(files []git.FileItem) {
+	marked := make(map[string]bool)
+	for _, file := range m.Files {
+		if file.Selected {
+			marked[file.Name] = true
+		}
+	}
+	for i := range files {
+		files[i].Selected = marked[files[i].Name]
+	}
+	m.Files = files
+}
+
+// MarkedFiles returns the files marked for a bulk action.
+func (m Model) MarkedFiles() []git.FileItem {
+	var marked []git.FileItem
+	for _, file := range m.Files {
+		if file.Selected {
+			marked = append(marked, file)
+		}
+	}
+	return marked
+}
+
+// HasFilesIn reports whether any changed file is inside directory dir.
+func (m Model) HasFilesIn(dir string) bool {
+	for _, file := range m.Files {
+		if strings.HasPrefix(file.Name, dir+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// DiffExternal reports whether diffs are rendered by external tools. Diffs
+// of past commits are always rendered by froggit.
+func (m Model) DiffExternal() bool {
+	return m.DiffRenderer.Enabled() && !m.DiffBuiltin && m.DiffRev == ""
+}
+
+// EnableDashboard switches to the dashboard layout and loads the data its
+// panels show beside the file list.
+func (m *Model) EnableDashboard() {
+	m.Dashboard = true
+	m.Stashes, m.StashInfos = loadStashes()
+	m.RecentCommits = loadRecentCommits()
+	m.DetailKey = ""
+}
+
+// recentCommitCount is how many commits the dashboard commits panel shows.
+const recentCommitCount = 200
 
-	go func() {
-		files, _ := git.GetModifiedFiles()
-		filesCh <- files
-	}()
-	go func() {
+// loadRecentCommits reads the log graph shown in the dashboard commits panel.
+func loadRecentCommits() []string {
+	graph, err := git.RecentLog(recentCommitCount)
+	if err != nil {
+		return nil
+	}
+	return strings.Split(strings.TrimSpace(graph), "\n")
+}
+
+// loadBranches returns the local branches with tracking info, their names and
+// the current branch. An unborn branch has no ref yet, so git branch is used
+// as a fallback for the names.
+func loadBranches() ([]git.BranchInfo, []string, string) {
+	infos, err := git.GetBranchInfos()
+	if err != nil || len(infos) == 0 {
 		branches, current := git.GetBranches()
-		branchesCh <- branches
-		currentCh <- current
-	}()
-	go func() {
-		remotes, _ := git.GetRemotes()
-		remotesCh <- remotes
-	}()
-
-	branches := <-branchesCh
-	current := <-currentCh
-	go func() {
-		hasRemoteChanges, _ := git.HasRemoteChangesWithFetch(current, false)
-		hasRemoteChangesCh <- hasRemoteChanges
-	}()
-
-	m.Files = <-filesCh
-	m.Branches = branches
-	m.Remotes = <-remotesCh
-	m.CurrentBranch = current
-	m.HasRemoteChanges = <-hasRemoteChangesCh
-
-	stashesCh := make(chan []string)
-	go func() {
-		stashOutput, _ := git.StashList()
-		stashes := parseStashList(stashOutput)
-		stashesCh <- stashes
-	}()
-	m.Stashes = <-stashesCh
+		return nil, branches, current
+	}
+
+	branches := make([]string, 0, len(infos))
+	current := ""
+	for _, info := range infos {
+		branches = append(branches, info.Name)
+		if info.Current {
+			current = info.Name
+		}
+	}
+	if current == "" {
+		// Detached HEAD: git branch describes it, e.g. "(HEAD detached at 1a2b3c4)".
+		_, current = git.GetBranches()
+	}
+	return infos, branches, current
+}
+
+// BranchInfo returns the tracking details for the branch at index i, if loaded.
+func (m Model) BranchInfo(i int) (git.BranchInfo, bool) {
+	if i < 0 || i >= len(m.BranchInfos) || len(m.BranchInfos) != len(m.Branches) {
+		return git.BranchInfo{}, false
+	}
+	return m.BranchInfos[i], true
+}
+
+// FilterActive reports whether a filter applies to the current view.
+func (m Model) FilterActive() bool {
+	return m.Filter != "" && m.FilterView == m.CurrentView
+}
+
+// SearchActive reports whether a search applies to the current view.
+func (m Model) SearchActive() bool {
+	return m.Search != "" && m.SearchView == m.CurrentView
+}
+
+// BranchRowCount is the number of rows in BranchView: local branches followed
+// by remote-tracking branches.
+func (m Model) BranchRowCount() int {
+	return len(m.Branches) + len(m.RemoteBranches)
+}
+
+// SelectedRemoteBranch returns the remote branch under the cursor in BranchView.
+func (m Model) SelectedRemoteBranch() (git.RemoteBranch, bool) {
+	i := m.Cursor - len(m.Branches)
+	if i < 0 || i >= len(m.RemoteBranches) {
+		return git.RemoteBranch{}, false
+	}
+	return m.RemoteBranches[i], true
+}
+
+// IgnorePatterns returns the patterns IgnoreView offers for IgnorePath in
+// the selected ignore file.
+func (m Model) IgnorePatterns() []string {
+	dir := ""
+	if m.IgnoreTarget < len(m.IgnoreTargets) {
+		dir = m.IgnoreTargets[m.IgnoreTarget].Dir
+	}
+	return git.IgnorePatterns(m.IgnorePath, dir)
+}
+
+// CompareRefMatches returns the refs offered for the ref being picked in
+// CompareView: those containing what was typed so far.
+func (m Model) CompareRefMatches() []string {
+	typed := m.CompareRefs[m.CompareField]
+	var matches []string
+	for _, ref := range m.CompareRefList {
+		if strings.Contains(strings.ToLower(ref), strings.ToLower(typed)) {
+			matches = append(matches, ref)
+		}
+	}
+	return matches
+}
+
+// FilePickMatches returns the tracked files whose path contains the text
+// typed in FilePickView.
+func (m Model) FilePickMatches() []string {
+	typed := strings.ToLower(m.FilePickInput)
+	var matches []string
+	for _, file := range m.FilePickList {
+		if strings.Contains(strings.ToLower(file), typed) {
+			matches = append(matches, file)
+		}
+	}
+	return matches
+}
+
+// CompareRows returns the number of rows the cursor moves over in
+// CompareView: the commits in each direction, then the files.
+func (m Model) CompareRows() int {
+	return len(m.CompareAhead) + len(m.CompareBehind) + len(m.CompareFiles)
+}
+
+// loadStashes reads the stash list with the date and files of each stash.
+func loadStashes() ([]string, []git.StashInfo) {
+	stashOutput, _ := git.StashList()
+	infos, _ := git.GetStashInfos()
+	return parseStashList(stashOutput), infos
+}
+
+// StashInfoOf returns the details of the stash ref.
+func (m Model) StashInfoOf(ref string) (git.StashInfo, bool) {
+	for _, info := range m.StashInfos {
+		if info.Ref == ref {
+			return info, true
+		}
+	}
+	return git.StashInfo{}, false
 }
 
 func parseStashList(output string) []string {
@@ -1,6 +1,7 @@
 package model
 
 import (
+	"froggit/internal/git"
 	"testing"
 )
 
@@ -23,3 +24,41 @@ func TestSetConflictFiles(t *testing.T) {
 		t.Fatalf("expected 1 rebase conflict file, got %d", len(m.RebaseConflictFiles))
 	}
 }
+
+func TestRefreshCoalescesAndDropsStaleParts(t *testing.T) {
+	m := &Model{}
+	m.Refresh(RefreshFiles)
+	m.Refresh(RefreshStashes)
+	scope, ok := m.StartRefresh()
+	if !ok || scope != RefreshFiles|RefreshStashes {
+		t.Fatalf("expected one load of files and stashes, got %v %v", scope, ok)
+	}
+
+	m.Refresh(RefreshFiles)
+	if _, ok := m.StartRefresh(); ok {
+		t.Fatalf("expected no second load while one is running")
+	}
+
+	m.FinishRefresh(RepoData{
+		Scope:   scope,
+		Files:   []git.FileItem{{Name: "old.go"}},
+		Stashes: []string{"stash@{0}"},
+	})
+	if len(m.Files) != 0 {
+		t.Fatalf("expected the stale files to be dropped, got %v", m.Files)
+	}
+	if len(m.Stashes) != 1 {
+		t.Fatalf("expected the stashes to be applied, got %v", m.Stashes)
+	}
+	if scope, ok := m.StartRefresh(); !ok || scope != RefreshFiles {
+		t.Fatalf("expected the files to be loaded again, got %v %v", scope, ok)
+	}
+}
+
+func TestSetFilesKeepsMarks(t *testing.T) {
+	m := &Model{Files: []git.FileItem{{Name: "a.go", Selected: true}, {Name: "b.go"}}}
+	m.SetFiles([]git.FileItem{{Name: "b.go"}, {Name: "a.go"}, {Name: "c.go"}})
+	if marked := m.MarkedFiles(); len(marked) != 1 || marked[0].Name != "a.go" {
+		t.Fatalf("expected a.go to stay marked, got %+v", marked)
+	}
+}
@@ -70,7 +70,7 @@ func HandleQuickStartAction(option int, hasGh bool) {
 			}
 		}
 
-		m := model.InitialModel()
+		m := model.InitialModel(git.StatusOptions{})
 		m.CurrentView = model.RepositoryListView
 		m = update.ShowRepositoryList(m, update.GetGhClient())
 		app := App{M: m, C: cfg}
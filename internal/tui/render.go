@@ -7,31 +7,24 @@ import (
 	"froggit/internal/tui/model"
 	"froggit/internal/tui/styles"
 	view "froggit/internal/tui/views"
-	"os"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
-	"golang.org/x/term"
 )
 
+// Render renders the model as it is laid out by Frame.
 func Render(m model.Model, cfg config.Config) string {
-	var sb strings.Builder
-
-	if cfg.Ui.Branding && m.CurrentView != model.QuickStartView {
-		sb.WriteString(styles.TitleStyle.Render(branding.RenderTitle()) + "\n\n")
-	}
+	_, frame := Frame(m, cfg)
+	return frame
+}
 
-	if m.CurrentView != model.QuickStartView {
-		sb.WriteString(fmt.Sprintf(" current branch: %s\n\n",
-			styles.HeaderStyle.Render(m.CurrentBranch),
-		))
-	}
+// renderView renders the current view on its own.
+func renderView(m model.Model) string {
+	var sb strings.Builder
 
 	switch m.CurrentView {
 	case model.QuickStartView:
 		sb.WriteString(view.RenderQuickStartView(m))
-	case model.FileView:
-		sb.WriteString(view.RenderFileView(m))
 	case model.CommitView:
 		sb.WriteString(view.RenderCommitView(m))
 	case model.BranchView:
@@ -64,8 +57,58 @@ func Render(m model.Model, cfg config.Config) string {
 		sb.WriteString(view.RenderStashMessageView(m))
 	case model.DiffView:
 		sb.WriteString(view.RenderDiffView(m))
+	case model.AskpassView:
+		sb.WriteString(view.RenderAskpassView(m))
+	case model.PushView:
+		sb.WriteString(view.RenderPushView(m))
+	case model.PullView:
+		sb.WriteString(view.RenderPullView(m))
+	case model.SetUpstreamView:
+		sb.WriteString(view.RenderSetUpstreamView(m))
+	case model.RenameBranchView:
+		sb.WriteString(view.RenderRenameBranchView(m))
+	case model.CleanupView:
+		sb.WriteString(view.RenderCleanupView(m))
+	case model.LogSearchView:
+		sb.WriteString(view.RenderLogSearchView(m))
+	case model.StashInputView:
+		sb.WriteString(view.RenderStashInputView(m))
+	case model.IgnoreView:
+		sb.WriteString(view.RenderIgnoreView(m))
+	case model.IgnoreCheckView:
+		sb.WriteString(view.RenderIgnoreCheckView(m))
+	case model.BlameView:
+		sb.WriteString(view.RenderBlameView(m))
+	case model.HistoryView:
+		sb.WriteString(view.RenderHistoryView(m))
+	case model.CompareView:
+		sb.WriteString(view.RenderCompareView(m))
+	case model.FilePickView:
+		sb.WriteString(view.RenderFilePickView(m))
+	}
+
+	return sb.String()
+}
+
+// renderHeader renders the title and current branch shown above every view.
+func renderHeader(m model.Model, cfg config.Config) string {
+	if m.CurrentView == model.QuickStartView {
+		return ""
+	}
+
+	var sb strings.Builder
+	if cfg.Ui.Branding {
+		sb.WriteString(styles.TitleStyle.Render(branding.RenderTitle()) + "\n\n")
 	}
+	sb.WriteString(fmt.Sprintf(" current branch: %s\n\n",
+		styles.HeaderStyle.Render(m.CurrentBranch),
+	))
+	return sb.String()
+}
 
+// renderFooter renders the status message and progress spinners shown below every view.
+func renderFooter(m model.Model) string {
+	var sb strings.Builder
 	if m.Message != "" {
 		sb.WriteString("\n")
 		switch m.MessageType {
@@ -90,21 +133,12 @@ func Render(m model.Model, cfg config.Config) string {
 		))
 	}
 
-	content := sb.String()
-
-	switch strings.ToLower(cfg.Ui.Position) {
-	case "center":
-		return renderCentered(content)
-	case "right":
-		return renderRight(content)
-	default:
-		return content
-	}
+	return sb.String()
 }
 
-func renderCentered(content string) string {
-	width, height, err := term.GetSize(int(os.Stdout.Fd()))
-	if err != nil || width <= 0 {
+func renderCentered(m model.Model, content string) string {
+	width, height := m.Width, m.Height
+	if width <= 0 {
 		width = 80
 	}
 	if height <= 0 {
@@ -126,9 +160,9 @@ func renderCentered(content string) string {
 	return centerStyle.Render(content)
 }
 
-func renderRight(content string) string {
-	width, _, err := term.GetSize(int(os.Stdout.Fd()))
-	if err != nil || width <= 0 {
+func renderRight(m model.Model, content string) string {
+	width := m.Width
+	if width <= 0 {
 		width = 80
 	}
 
@@ -207,4 +207,9 @@ var (
 				Background(lipgloss.Color(GrayDark)).
 				Bold(true).
 				Padding(0, 1)
+
+	FilterMatchStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color(Orange)).
+				Underline(true).
+				Bold(true)
 )
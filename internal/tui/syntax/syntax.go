@@ -0,0 +1,146 @@
+// Package syntax highlights the code in diffs, choosing the lexer from the
+// name of each file the diff touches.
+package syntax
+
+import (
+	"strings"
+
+	"froggit/internal/tui/icons"
+
+	"github.com/alecthomas/chroma/v2"
+	"github.com/alecthomas/chroma/v2/lexers"
+	"github.com/alecthomas/chroma/v2/styles"
+)
+
+// MaxLines is the size above which diffs are left unhighlighted, since
+// lexing them would make opening them noticeably slow.
+const MaxLines = 5000
+
+// theme colors the tokens; its backgrounds are ignored so diff tints show.
+var theme = styles.Get("monokai")
+
+// Span is a piece of highlighted code.
+type Span struct {
+	Text  string
+	Color string // foreground as "#rrggbb", empty for the default
+	Bold  bool
+}
+
+// HighlightDiff highlights a unified diff. For each line it returns the spans
+// of the code after the +, - or space prefix, or nil for header lines and
+// lines of files whose language isn't known. It returns nil for diffs longer
+// than MaxLines.
+func HighlightDiff(lines []string) [][]Span {
+	if len(lines) > MaxLines {
+		return nil
+	}
+
+	result := make([][]Span, len(lines))
+	start := 0
+	for i := 1; i <= len(lines); i++ {
+		if i == len(lines) || strings.HasPrefix(lines[i], "diff ") {
+			highlightFile(lines, start, i, result)
+			start = i
+		}
+	}
+	return result
+}
+
+// highlightFile highlights lines[start:end], the part of a diff for one file.
+// The old and new versions are lexed separately so removed and added lines
+// are highlighted in the context of the code around them.
+func highlightFile(lines []string, start, end int, result [][]Span) {
+	name := ""
+	var oldLines, newLines []int
+	inHunk := false
+	for i := start; i < end; i++ {
+		line := lines[i]
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			if _, b, ok := strings.Cut(line, " b/"); ok {
+				name = b
+			}
+		case strings.HasPrefix(line, "+++ b/"):
+			name = strings.TrimPrefix(line, "+++ b/")
+		case strings.HasPrefix(line, "@@"):
+			inHunk = true
+		case !inHunk:
+		case strings.HasPrefix(line, "-"):
+			oldLines = append(oldLines, i)
+		case strings.HasPrefix(line, "+"):
+			newLines = append(newLines, i)
+		case strings.HasPrefix(line, " "), line == "":
+			oldLines = append(oldLines, i)
+			newLines = append(newLines, i)
+		}
+	}
+
+	lexer := lexers.Get(icons.GetLanguageForFile(name))
+	if lexer == nil {
+		return
+	}
+	lexer = chroma.Coalesce(lexer)
+
+	// context lines take their highlighting from the new version
+	highlightLines(lexer, lines, oldLines, result)
+	highlightLines(lexer, lines, newLines, result)
+}
+
+// highlightLines lexes the code of the given diff lines as one text and
+// stores the spans of each line in result.
+func highlightLines(lexer chroma.Lexer, lines []string, indices []int, result [][]Span) {
+	if len(indices) == 0 {
+		return
+	}
+	code := make([]string, len(indices))
+	for k, i := range indices {
+		code[k] = codeOf(lines[i])
+	}
+
+	iterator, err := lexer.Tokenise(nil, strings.Join(code, "\n")+"\n")
+	if err != nil {
+		return
+	}
+
+	spans := make([][]Span, len(indices))
+	k := 0
+	for _, token := range iterator.Tokens() {
+		entry := theme.Get(token.Type)
+		for n, part := range strings.Split(token.Value, "\n") {
+			if n > 0 {
+				k++
+			}
+			if part == "" || k >= len(spans) {
+				continue
+			}
+			span := Span{Text: part, Bold: entry.Bold == chroma.Yes}
+			if entry.Colour.IsSet() {
+				span.Color = entry.Colour.String()
+			}
+			spans[k] = append(spans[k], span)
+		}
+	}
+
+	for k, i := range indices {
+		// keep only highlighting that reproduces the line exactly
+		if joined(spans[k]) == code[k] {
+			result[i] = spans[k]
+		}
+	}
+}
+
+// codeOf strips the +, - or space prefix of a diff line.
+func codeOf(line string) string {
+	if line == "" {
+		return ""
+	}
+	return line[1:]
+}
+
+func joined(spans []Span) string {
+	var sb strings.Builder
+	for _, span := range spans {
+		sb.WriteString(span.Text)
+	}
+	return sb.String()
+}
@@ -0,0 +1,33 @@
+package syntax
+
+import "testing"
+
+func TestHighlightDiff(t *testing.T) {
+	lines := []string{
+		"diff --git a/main.go b/main.go",
+		"--- a/main.go",
+		"+++ b/main.go",
+		"@@ -1,2 +1,2 @@",
+		" package main",
+		"-func old() {}",
+		"+func new() {}",
+	}
+	spans := HighlightDiff(lines)
+	for i := 0; i < 4; i++ {
+		if spans[i] != nil {
+			t.Fatalf("expected header line %d to be unhighlighted", i)
+		}
+	}
+	for i := 4; i < len(lines); i++ {
+		if got := joined(spans[i]); got != lines[i][1:] {
+			t.Fatalf("line %d: spans join to %q; want %q", i, got, lines[i][1:])
+		}
+	}
+	if spans[5][0].Color == "" {
+		t.Fatalf("expected the func keyword to be colored")
+	}
+
+	if HighlightDiff([]string{"diff --git a/notes b/notes", "@@ -1 +1 @@", "+text"})[2] != nil {
+		t.Fatalf("expected files of unknown language to be unhighlighted")
+	}
+}
@@ -47,7 +47,7 @@ func HandleSwitchBranchMsg(m model.Model, msg any) (model.Model, bool) {
 		}
 
 		m.CurrentBranch = msg.TargetBranch
-		m.RefreshData()
+		m.Refresh(model.RefreshHead)
 
 		if msg.NextAction == "merge" {
 			err := git.Merge(msg.SourceBranch)
@@ -60,6 +60,7 @@ func HandleSwitchBranchMsg(m model.Model, msg any) (model.Model, bool) {
 			conflicts, _ := git.GetConflictFiles()
 			if len(conflicts) > 0 {
 				m.LogLines = conflicts
+				m.ConflictCursor = 0
 				m.Message = fmt.Sprintf("Conflicts detected while merging %s into %s. Please resolve them and use [P] Proceed or [X] Cancel.", msg.SourceBranch, msg.TargetBranch)
 				m.MessageType = "warning"
 			} else {
@@ -80,6 +81,7 @@ func HandleSwitchBranchMsg(m model.Model, msg any) (model.Model, bool) {
 			conflicts, _ := git.GetConflictFiles()
 			if len(conflicts) > 0 {
 				m.LogLines = conflicts
+				m.ConflictCursor = 0
 				m.Message = fmt.Sprintf("Conflicts detected while rebasing %s onto %s. Please resolve them and use [P] Proceed or [X] Cancel.", msg.SourceBranch, msg.TargetBranch)
 				m.MessageType = "warning"
 			} else {
@@ -88,7 +90,7 @@ func HandleSwitchBranchMsg(m model.Model, msg any) (model.Model, bool) {
 				m.CurrentView = model.FileView
 				m.DialogTarget = ""
 				m.LogLines = nil
-				m.RefreshData()
+				m.Refresh(model.RefreshHead)
 			}
 			return m, true
 		}
@@ -1,22 +1,36 @@
 package async
 
 import (
+	"os/exec"
+	"strings"
 	"time"
 
+	"froggit/internal/askpass"
 	"froggit/internal/copilot"
 	"froggit/internal/git"
+	"froggit/internal/tui/model"
+	"froggit/internal/tui/syntax"
 	"froggit/internal/tui/update/messages"
+	"froggit/internal/watcher"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
 
 type (
-	PushMsg               struct{ Err error }
+	PushMsg               struct{ Err error; DryRun bool; Output string }
 	FetchMsg              struct{ Err error }
-	PullMsg               struct{ Err error }
+	PullMsg               struct{ Err error; Strategy string; Result git.PullResult }
 	SpinnerTickMsg        struct{}
 	RemoteChangesCheckMsg struct{ HasChanges bool; Err error }
 	AICommitMsg           struct{ Message string; Err error }
+	AskpassPromptMsg      struct{ Request *askpass.Request }
+	RemoteBranchDeleteMsg struct{ Branch git.RemoteBranch; Err error }
+	RemoteBranchRenameMsg struct{ Remote, NewName string; Err error }
+	LogSearchMsg          struct{ Kind git.LogSearchKind; Query, Output string; Err error }
+	ExternalToolMsg       struct{ Tool, File string; Err error }
+	RepoChangedMsg        struct{ Scope model.RefreshScope; Err error }
+	RefreshMsg            struct{ Data model.RepoData }
+	DetailMsg             struct{ Key string; Lines []string; Highlights [][]syntax.Span }
 )
 
 // spinner returns a Cmd that emits spinnerTickMsg every 100ms.
@@ -39,6 +53,14 @@ func PerformPushWithConfig(defaultBranch string) tea.Cmd {
 	}
 }
 
+// PerformPushWithOptions runs a push configured in the push dialog.
+func PerformPushWithOptions(opts git.PushOptions) tea.Cmd {
+	return func() tea.Msg {
+		output, err := git.PushWithOptions(opts)
+		return PushMsg{Err: err, DryRun: opts.DryRun, Output: output}
+	}
+}
+
 // performFetch runs git.Fetch asynchronously and returns a fetchMsg.
 func PerformFetch() tea.Cmd {
 	return func() tea.Msg {
@@ -46,16 +68,47 @@ func PerformFetch() tea.Cmd {
 	}
 }
 
+// PerformFetchRemote fetches a single remote and returns a FetchMsg.
+func PerformFetchRemote(remote string) tea.Cmd {
+	return func() tea.Msg {
+		return FetchMsg{Err: git.FetchRemote(remote)}
+	}
+}
+
+// PerformDeleteRemoteBranch deletes a branch on its remote.
+func PerformDeleteRemoteBranch(branch git.RemoteBranch) tea.Cmd {
+	return func() tea.Msg {
+		return RemoteBranchDeleteMsg{Branch: branch, Err: git.DeleteRemoteBranch(branch)}
+	}
+}
+
+// PerformRenameRemoteBranch publishes a renamed branch and deletes its old name on the remote.
+func PerformRenameRemoteBranch(remote, oldRemoteName, newName string) tea.Cmd {
+	return func() tea.Msg {
+		err := git.RenameRemoteBranch(remote, oldRemoteName, newName)
+		return RemoteBranchRenameMsg{Remote: remote, NewName: newName, Err: err}
+	}
+}
+
+// PerformLogSearch runs git.SearchLog asynchronously, as pickaxe searches can be slow.
+func PerformLogSearch(kind git.LogSearchKind, query string) tea.Cmd {
+	return func() tea.Msg {
+		output, err := git.SearchLog(kind, query)
+		return LogSearchMsg{Kind: kind, Query: query, Output: output, Err: err}
+	}
+}
+
 func PerformAutoFetch() tea.Cmd {
 	return func() tea.Msg {
 		return FetchMsg{Err: git.FetchWithConfig(true)}
 	}
 }
 
-// performPull runs git.Pull asynchronously and returns a pullMsg.
-func PerformPull() tea.Cmd {
+// PerformPull runs git.PullWithStrategy asynchronously and returns a PullMsg.
+func PerformPull(strategy string) tea.Cmd {
 	return func() tea.Msg {
-		return PullMsg{Err: git.Pull()}
+		result, err := git.PullWithStrategy(strategy)
+		return PullMsg{Err: err, Strategy: strategy, Result: result}
 	}
 }
 
@@ -111,3 +164,72 @@ func PerformAICommitGeneration() tea.Cmd {
 		return AICommitMsg{Message: msg, Err: err}
 	}
 }
+
+// WaitForAskpass waits for the next credential prompt relayed by the askpass server.
+func WaitForAskpass(s *askpass.Server) tea.Cmd {
+	if s == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		return AskpassPromptMsg{Request: <-s.Requests()}
+	}
+}
+
+// WaitForRepoChange waits for the watcher to notice a change made outside froggit.
+func WaitForRepoChange(w *watcher.Watcher) tea.Cmd {
+	if w == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		<-w.Changes()
+		return RepoChangedMsg{Scope: repoChangeScope(w.TakeChanges()), Err: w.TakeError()}
+	}
+}
+
+// repoChangeScope returns the parts of the repository state to load again
+// after change.
+func repoChangeScope(change watcher.Change) model.RefreshScope {
+	var scope model.RefreshScope
+	if change&watcher.WorktreeChanged != 0 {
+		scope |= model.RefreshFiles
+	}
+	if change&watcher.RefsChanged != 0 {
+		scope |= model.RefreshHead
+	}
+	if change&watcher.StashChanged != 0 {
+		scope |= model.RefreshStashes
+	}
+	return scope
+}
+
+// PerformRefresh loads the parts of the repository state in scope in the
+// background.
+func PerformRefresh(scope model.RefreshScope, dashboard bool, status git.StatusOptions) tea.Cmd {
+	return func() tea.Msg {
+		return RefreshMsg{Data: model.LoadRepoData(scope, dashboard, status)}
+	}
+}
+
+// LoadDetail runs load in the background and returns its output, split into
+// lines, as a DetailMsg tagged with key, the selection it belongs to.
+func LoadDetail(key string, load func() (string, error), highlight bool) tea.Cmd {
+	return func() tea.Msg {
+		output, err := load()
+		if err != nil {
+			return DetailMsg{Key: key, Lines: []string{err.Error()}}
+		}
+		msg := DetailMsg{Key: key, Lines: strings.Split(strings.TrimRight(output, "\n"), "\n")}
+		if highlight {
+			msg.Highlights = syntax.HighlightDiff(msg.Lines)
+		}
+		return msg
+	}
+}
+
+// RunExternalTool suspends the TUI while an interactive tool such as git's
+// difftool or mergetool runs in the terminal.
+func RunExternalTool(tool, file string, cmd *exec.Cmd) tea.Cmd {
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return ExternalToolMsg{Tool: tool, File: file, Err: err}
+	})
+}
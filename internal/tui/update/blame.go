@@ -0,0 +1,186 @@
+package update
+
+import (
+	"fmt"
+	"strings"
+
+	"froggit/internal/git"
+	"froggit/internal/tui/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// blamePage is how many lines pgup and pgdown move in BlameView.
+const blamePage = 20
+
+// OpenBlameView shows who last changed each line of the file under the
+// cursor.
+func OpenBlameView(m model.Model) model.Model {
+	if m.Cursor >= len(m.Files) {
+		m.Message = "⚠ No file selected or no files available"
+		m.MessageType = "warning"
+		return m
+	}
+	file := m.Files[m.Cursor]
+	if file.Status == "??" || strings.Contains(file.Status, "A") {
+		m.Message = "⚠ The file has no history to blame yet"
+		m.MessageType = "warning"
+		return m
+	}
+
+	return openBlame(m, file.Name)
+}
+
+// openBlame shows who last changed each line of the tracked file path.
+func openBlame(m model.Model, path string) model.Model {
+	m.BlameStack = nil
+	m, err := loadBlame(m, model.BlameFrame{Path: path}, 0)
+	if err != nil {
+		m.Message = fmt.Sprintf("✗ %s", err)
+		m.MessageType = "error"
+		return m
+	}
+	m.CurrentView = model.BlameView
+	m.Message = ""
+	return m
+}
+
+// loadBlame blames the file of frame and puts the cursor on line.
+func loadBlame(m model.Model, frame model.BlameFrame, line int) (model.Model, error) {
+	lines, err := git.Blame(frame.Path, frame.Rev)
+	if err != nil {
+		return m, err
+	}
+	m.Blame = frame
+	m.BlameLines = lines
+	m.BlameCursor = max(0, min(line, len(lines)-1))
+	return m, nil
+}
+
+// HandleBlameKey handles key messages in BlameView.
+func HandleBlameKey(m model.Model, msg tea.KeyMsg) (model.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		if n := len(m.BlameStack); n > 0 {
+			frame := m.BlameStack[n-1]
+			m.BlameStack = m.BlameStack[:n-1]
+			m, err := loadBlame(m, frame, frame.Cursor)
+			if err != nil {
+				m.Message = fmt.Sprintf("✗ %s", err)
+				m.MessageType = "error"
+			}
+			return m, nil
+		}
+		return closeBlame(m), nil
+
+	case "up", "k":
+		m.BlameCursor = max(0, m.BlameCursor-1)
+	case "down", "j":
+		m.BlameCursor = max(0, min(len(m.BlameLines)-1, m.BlameCursor+1))
+	case "pgup":
+		m.BlameCursor = max(0, m.BlameCursor-blamePage)
+	case "pgdown":
+		m.BlameCursor = max(0, min(len(m.BlameLines)-1, m.BlameCursor+blamePage))
+	case "home", "g":
+		m.BlameCursor = 0
+	case "end", "G":
+		m.BlameCursor = max(0, len(m.BlameLines)-1)
+
+	case "enter":
+		return jumpToBlameCommit(m)
+
+	case "p":
+		return blamePrevious(m), nil
+
+	case "H":
+		hash := ""
+		if m.BlameCursor < len(m.BlameLines) {
+			hash = m.BlameLines[m.BlameCursor].Hash
+		}
+		return OpenHistoryView(m, blameHistoryPath(m), model.BlameView, hash), nil
+	}
+	return m, nil
+}
+
+// closeBlame goes back to FileView with the blamed file selected.
+func closeBlame(m model.Model) model.Model {
+	for i, file := range m.Files {
+		if file.Name == m.Blame.Path {
+			m.Cursor = i
+			break
+		}
+	}
+	m.CurrentView = model.FileView
+	m.BlameLines = nil
+	m.Message = ""
+	return m
+}
+
+// blamePrevious blames the file again as it was just before the commit of
+// the selected line, to see past a commit that only moved or reformatted it.
+func blamePrevious(m model.Model) model.Model {
+	if m.BlameCursor >= len(m.BlameLines) {
+		return m
+	}
+	line := m.BlameLines[m.BlameCursor]
+	switch {
+	case line.Uncommitted():
+		m.Message = "⚠ The line isn't committed yet"
+		m.MessageType = "warning"
+		return m
+	case line.Previous == "":
+		m.Message = fmt.Sprintf("⚠ The line was added in %s, the first commit of the file", shortHash(line.Hash))
+		m.MessageType = "warning"
+		return m
+	}
+
+	current := m.Blame
+	current.Cursor = m.BlameCursor
+	frame := model.BlameFrame{Path: line.PreviousPath, Rev: line.Previous}
+	// the line is usually near where it was in the commit that changed it
+	m, err := loadBlame(m, frame, line.OrigLine-1)
+	if err != nil {
+		m.Message = fmt.Sprintf("✗ %s", err)
+		m.MessageType = "error"
+		return m
+	}
+	m.BlameStack = append(m.BlameStack, current)
+	m.Message = fmt.Sprintf("Blaming %s before %s, [esc] steps forward again", line.PreviousPath, shortHash(line.Hash))
+	m.MessageType = "info"
+	return m
+}
+
+// jumpToBlameCommit shows the commit of the selected line in the log graph.
+func jumpToBlameCommit(m model.Model) (model.Model, tea.Cmd) {
+	if m.BlameCursor >= len(m.BlameLines) {
+		return m, nil
+	}
+	line := m.BlameLines[m.BlameCursor]
+	if line.Uncommitted() {
+		m.Message = "⚠ The line isn't committed yet"
+		m.MessageType = "warning"
+		return m, nil
+	}
+
+	m, cmd := OpenLogGraphView(m)
+	if m.CurrentView != model.LogGraphView {
+		return m, cmd
+	}
+	m.LogBack = model.BlameView
+	for i, logLine := range m.LogLines {
+		if hash := git.LogLineHash(logLine); hash != "" && strings.HasPrefix(line.Hash, hash) {
+			m.Cursor = i
+			break
+		}
+	}
+	m.Message = fmt.Sprintf("%s %s, [esc] returns to blame", shortHash(line.Hash), line.Summary)
+	m.MessageType = "info"
+	return m, cmd
+}
+
+func shortHash(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
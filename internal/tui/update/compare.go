@@ -0,0 +1,230 @@
+package update
+
+import (
+	"fmt"
+	"slices"
+
+	"froggit/internal/git"
+	"froggit/internal/tui/model"
+	"froggit/internal/utils"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// OpenCompareView starts picking the refs to compare, prefilled with base and
+// target. The picker starts on the first of them that is still missing.
+func OpenCompareView(m model.Model, base, target string) model.Model {
+	refs, err := git.ListRefs()
+	if err != nil {
+		m.Message = fmt.Sprintf("✗ %s", err)
+		m.MessageType = "error"
+		return m
+	}
+	if base == target {
+		target = ""
+	}
+
+	m.CompareRefList = refs
+	m.CompareRefs = [model.CompareFieldCount]string{base, target}
+	m.CompareField = model.CompareFieldBase
+	if base != "" {
+		m.CompareField = model.CompareFieldTarget
+	}
+	m.CompareRefCursor = compareRefIndex(m)
+	m.ComparePicking = true
+	m.CompareAhead, m.CompareBehind, m.CompareFiles = nil, nil, nil
+	m.CurrentView = model.CompareView
+	m.Message = ""
+	return m
+}
+
+// compareRefIndex returns the entry of CompareRefMatches matching the ref
+// typed for the picked field exactly, or the first one.
+func compareRefIndex(m model.Model) int {
+	return max(0, slices.Index(m.CompareRefMatches(), m.CompareRefs[m.CompareField]))
+}
+
+// HandleCompareKey handles key messages in CompareView.
+func HandleCompareKey(m model.Model, msg tea.KeyMsg) (model.Model, tea.Cmd) {
+	if m.ComparePicking {
+		return handleComparePicker(m, msg), nil
+	}
+
+	last := m.CompareRows() - 1
+	switch msg.String() {
+	case "esc":
+		m.ComparePicking = true
+		m.CompareRefCursor = compareRefIndex(m)
+		m.Message = ""
+	case "up", "k":
+		m.CompareCursor = max(0, m.CompareCursor-1)
+	case "down", "j":
+		m.CompareCursor = max(0, min(last, m.CompareCursor+1))
+	case "pgup":
+		m.CompareCursor = max(0, m.CompareCursor-blamePage)
+	case "pgdown":
+		m.CompareCursor = max(0, min(last, m.CompareCursor+blamePage))
+	case "home", "g":
+		m.CompareCursor = 0
+	case "end", "G":
+		m.CompareCursor = max(0, last)
+	case "enter":
+		return openCompareRow(m), nil
+	case "d":
+		return openCompareDiff(m, nil, "all files"), nil
+	case "s":
+		base, target := &m.CompareRefs[model.CompareFieldBase], &m.CompareRefs[model.CompareFieldTarget]
+		*base, *target = *target, *base
+		return compareRefs(m), nil
+	}
+	return m, nil
+}
+
+// handleComparePicker handles the keys picking the base and target refs.
+// Typing filters the branches and tags offered; a commit that matches none
+// of them is taken as typed.
+func handleComparePicker(m model.Model, msg tea.KeyMsg) model.Model {
+	matches := m.CompareRefMatches()
+	field := &m.CompareRefs[m.CompareField]
+	switch msg.String() {
+	case "esc":
+		m.CompareRefList = nil
+		m.CompareAhead, m.CompareBehind, m.CompareFiles = nil, nil, nil
+		m.CurrentView = model.FileView
+		m.Message = ""
+		return m
+	case "up":
+		m.CompareRefCursor = max(0, m.CompareRefCursor-1)
+	case "down":
+		m.CompareRefCursor = max(0, min(len(matches)-1, m.CompareRefCursor+1))
+	case "tab":
+		m.CompareField = (m.CompareField + 1) % model.CompareFieldCount
+		m.CompareRefCursor = compareRefIndex(m)
+	case "backspace":
+		if len(*field) > 0 {
+			*field = (*field)[:len(*field)-1]
+			m.CompareRefCursor = 0
+		}
+	case "enter":
+		if m.CompareRefCursor < len(matches) {
+			*field = matches[m.CompareRefCursor]
+		}
+		if *field == "" {
+			return m
+		}
+		// go on to the other ref until both are picked
+		other := (m.CompareField + 1) % model.CompareFieldCount
+		if m.CompareRefs[other] == "" {
+			m.CompareField = other
+			m.CompareRefCursor = compareRefIndex(m)
+			return m
+		}
+		return compareRefs(m)
+	default:
+		if len(msg.Runes) == 1 && utils.IsPrintableChar(msg.Runes[0]) && msg.Runes[0] != ' ' {
+			*field += string(msg.Runes)
+			m.CompareRefCursor = 0
+		}
+	}
+	return m
+}
+
+// compareRefs lists the commits each ref has that the other lacks, and the
+// files the target changed since it forked from the base.
+func compareRefs(m model.Model) model.Model {
+	base, target := m.CompareRefs[model.CompareFieldBase], m.CompareRefs[model.CompareFieldTarget]
+	for field, ref := range m.CompareRefs {
+		if !git.IsCommitish(ref) {
+			m.Message = fmt.Sprintf("✗ %s is not a branch, tag or commit", ref)
+			m.MessageType = "error"
+			m.CompareField = field
+			m.ComparePicking = true
+			return m
+		}
+	}
+	ahead, err := git.CommitsBetween(base, target)
+	if err == nil {
+		m.CompareBehind, err = git.CommitsBetween(target, base)
+	}
+	if err == nil {
+		m.CompareFiles, err = git.CompareFiles(base, target)
+	}
+	if err != nil {
+		m.Message = fmt.Sprintf("✗ %s", err)
+		m.MessageType = "error"
+		m.ComparePicking = true
+		return m
+	}
+
+	m.CompareAhead = ahead
+	m.CompareCursor = 0
+	m.ComparePicking = false
+	m.Message = ""
+	if m.CompareRows() == 0 {
+		m.Message = fmt.Sprintf("%s and %s point to the same content", base, target)
+		m.MessageType = "info"
+	}
+	return m
+}
+
+// openCompareRow shows the commit under the cursor, or the changes the target
+// made to the file under it.
+func openCompareRow(m model.Model) model.Model {
+	i := m.CompareCursor
+	if i < len(m.CompareAhead) {
+		return openCompareCommit(m, m.CompareAhead[i])
+	}
+	i -= len(m.CompareAhead)
+	if i < len(m.CompareBehind) {
+		return openCompareCommit(m, m.CompareBehind[i])
+	}
+	i -= len(m.CompareBehind)
+	if i >= len(m.CompareFiles) {
+		return m
+	}
+	file := m.CompareFiles[i]
+	paths := []string{file.Path}
+	if file.OldPath != "" {
+		paths = append(paths, file.OldPath)
+	}
+	return openCompareDiff(m, paths, file.Path)
+}
+
+func openCompareCommit(m model.Model, commit git.CommitInfo) model.Model {
+	m.DiffRev = commit.Hash
+	m.DiffBase = ""
+	m.DiffPaths = nil
+	m.DiffTitle = fmt.Sprintf("%s %s", shortHash(commit.Hash), commit.Summary)
+	return showCompareDiff(m)
+}
+
+// openCompareDiff shows the changes the target made to paths since it forked
+// from the base, or to every file when paths is empty.
+func openCompareDiff(m model.Model, paths []string, what string) model.Model {
+	if len(m.CompareFiles) == 0 {
+		m.Message = "⚠ No changes to show"
+		m.MessageType = "warning"
+		return m
+	}
+	base, target := m.CompareRefs[model.CompareFieldBase], m.CompareRefs[model.CompareFieldTarget]
+	m.DiffRev = target
+	m.DiffBase = base
+	m.DiffPaths = paths
+	m.DiffTitle = fmt.Sprintf("%s in %s...%s", what, base, target)
+	return showCompareDiff(m)
+}
+
+func showCompareDiff(m model.Model) model.Model {
+	m.DiffBack = model.CompareView
+	m.DiffViewOffset = 0
+	m, err := loadFileDiff(m)
+	if err != nil {
+		m.DiffRev = ""
+		m.DiffBase = ""
+		m.Message = fmt.Sprintf("✗ Error getting diff: %s", err)
+		m.MessageType = "error"
+		return m
+	}
+	m.CurrentView = model.DiffView
+	return m
+}
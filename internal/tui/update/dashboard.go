@@ -0,0 +1,152 @@
+package update
+
+import (
+	"froggit/internal/git"
+	"froggit/internal/tui/model"
+	"froggit/internal/tui/update/async"
+	"froggit/internal/utils"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// HandleDashboardKey switches focus between the dashboard panels. Keys it does
+// not handle go to the view of the focused panel.
+func HandleDashboardKey(m model.Model, msg tea.KeyMsg) (model.Model, bool) {
+	panel, ok := model.PanelOf(m.CurrentView)
+	if !m.Dashboard || !ok {
+		return m, false
+	}
+
+	switch msg.String() {
+	case "tab":
+		return FocusPanel(m, (panel+1)%model.PanelCount), true
+	case "shift+tab":
+		return FocusPanel(m, (panel+model.PanelCount-1)%model.PanelCount), true
+	case "1", "2", "3", "4":
+		return FocusPanel(m, int(msg.Runes[0]-'1')), true
+	case "b":
+		if panel == model.PanelFiles {
+			return FocusPanel(m, model.PanelBranches), true
+		}
+	case "S":
+		if panel == model.PanelFiles && m.AdvancedMode {
+			return FocusPanel(m, model.PanelStash), true
+		}
+	case "L":
+		if panel == model.PanelFiles {
+			return FocusPanel(m, model.PanelCommits), true
+		}
+	case "esc":
+		// panels stay on screen, so leaving one just moves the focus back to the files
+		if panel != model.PanelFiles && m.LogSearchTitle == "" {
+			return FocusPanel(m, model.PanelFiles), true
+		}
+	}
+	return m, false
+}
+
+// FocusPanel focuses a dashboard panel, restoring the cursor it had when it
+// last lost focus.
+func FocusPanel(m model.Model, panel int) model.Model {
+	if current, ok := model.PanelOf(m.CurrentView); ok {
+		m.PanelCursors[current] = m.Cursor
+	}
+
+	if panel == model.PanelCommits {
+		m.LogLines = m.RecentCommits
+		m.LogSearchTitle = ""
+	}
+
+	// filters and searches belong to the panel they were typed in
+	m.Filter = ""
+	m.FilterTyping = false
+	m.Search = ""
+	m.SearchTyping = false
+
+	m.Panel = panel
+	m.CurrentView = model.PanelView(panel)
+	m.Cursor = m.PanelCursors[panel]
+	m.Message = ""
+	utils.ValidateCursor(&m)
+	return m
+}
+
+// SyncDashboard keeps the focused panel in step with views opened by other
+// keys and starts loading the details of the selection into the main panel.
+func SyncDashboard(m model.Model) (model.Model, tea.Cmd) {
+	if !m.Dashboard {
+		return m, nil
+	}
+	panel, ok := model.PanelOf(m.CurrentView)
+	if !ok {
+		return m, nil
+	}
+	m.Panel = panel
+
+	key, title, load := dashboardDetail(m)
+	if key == m.DetailKey {
+		return m, nil
+	}
+	m.DetailKey = key
+	m.DetailTitle = title
+	m.DetailLines = nil
+	m.DetailHighlights = nil
+	if load == nil {
+		return m, nil
+	}
+	return m, async.LoadDetail(key, load, !m.DiffPlain)
+}
+
+// ApplyDetail shows details loaded by SyncDashboard, unless the selection
+// moved on while they loaded.
+func ApplyDetail(m model.Model, msg async.DetailMsg) model.Model {
+	if msg.Key != m.DetailKey {
+		return m
+	}
+	m.DetailLines = msg.Lines
+	m.DetailHighlights = msg.Highlights
+	return m
+}
+
+// dashboardDetail identifies the selection of the focused panel and returns
+// how to load its details.
+func dashboardDetail(m model.Model) (key, title string, load func() (string, error)) {
+	switch m.CurrentView {
+	case model.FileView:
+		if m.Cursor < len(m.Files) {
+			file := m.Files[m.Cursor]
+			key = "file:" + file.Status + ":" + file.Name
+			return key, "Diff " + file.Name, func() (string, error) {
+				return git.GetFileDiff(file.Name, file.Staged, m.DiffOptions)
+			}
+		}
+	case model.BranchView:
+		ref := ""
+		if branch, ok := m.SelectedRemoteBranch(); ok {
+			ref = branch.Ref()
+		} else if m.Cursor < len(m.Branches) {
+			ref = m.Branches[m.Cursor]
+		}
+		if ref != "" {
+			return "branch:" + ref, "Log " + ref, func() (string, error) {
+				return git.BranchLog(ref, 50)
+			}
+		}
+	case model.StashView:
+		if m.Cursor < len(m.Stashes) {
+			ref := git.GetStashRef(m.Stashes[m.Cursor])
+			return "stash:" + m.Stashes[m.Cursor], "Stash " + ref, func() (string, error) {
+				return git.StashShow(ref)
+			}
+		}
+	case model.LogGraphView:
+		if m.Cursor < len(m.LogLines) {
+			if hash := git.LogLineHash(m.LogLines[m.Cursor]); hash != "" {
+				return "commit:" + hash, "Commit " + hash, func() (string, error) {
+					return git.ShowCommit(hash)
+				}
+			}
+		}
+	}
+	return "", "", nil
+}
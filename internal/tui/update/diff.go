@@ -0,0 +1,305 @@
+package update
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"froggit/internal/editor"
+	"froggit/internal/git"
+	"froggit/internal/tui/model"
+	"froggit/internal/tui/syntax"
+	"froggit/internal/tui/update/async"
+	"froggit/internal/utils"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// maxDiffContext caps how far "+" widens the context around changes.
+const maxDiffContext = 50
+
+// OpenDiffView shows the diff of the file under the cursor.
+func OpenDiffView(m model.Model) model.Model {
+	m.DiffRev = ""
+	m.DiffBase = ""
+	m.DiffPaths = nil
+	m.DiffTitle = ""
+	m, err := loadFileDiff(m)
+	if err != nil {
+		m.Message = fmt.Sprintf("✗ Error getting diff: %s", err)
+		m.MessageType = "error"
+		return m
+	}
+	m.DiffViewOffset = 0
+	m.CurrentView = model.DiffView
+	return m
+}
+
+// closeDiff leaves DiffView for the view it was opened from.
+func closeDiff(m model.Model) model.Model {
+	m.CurrentView = model.FileView
+	if m.DiffRev != "" {
+		m.CurrentView = m.DiffBack
+	}
+	m.DiffRev = ""
+	m.DiffBase = ""
+	m.DiffPaths = nil
+	m.DiffTitle = ""
+	m.DiffLines = nil
+	m.DiffHighlights = nil
+	m.DiffViewOffset = 0
+	return m
+}
+
+// loadFileDiff reads the diff of the file under the cursor, or the change of
+// commit DiffRev, or what DiffRev changed since it forked from DiffBase, with
+// the current diff options.
+func loadFileDiff(m model.Model) (model.Model, error) {
+	if m.DiffBase != "" {
+		diff, err := git.RangeDiff(m.DiffBase, m.DiffRev, m.DiffPaths, m.DiffOptions)
+		if err != nil {
+			return m, err
+		}
+		return setDiffLines(m, diff), nil
+	}
+	if m.DiffRev != "" {
+		diff, err := git.CommitFileDiff(m.DiffRev, m.DiffPaths, m.DiffOptions)
+		if err != nil {
+			return m, err
+		}
+		return setDiffLines(m, diff), nil
+	}
+	if m.Cursor >= len(m.Files) {
+		return m, fmt.Errorf("no file selected")
+	}
+	file := m.Files[m.Cursor]
+	if m.DiffExternal() {
+		width := m.Width
+		if width <= 0 {
+			width = 80
+		}
+		diff, err := git.RenderFileDiff(file.Name, file.Staged, m.DiffOptions, m.DiffRenderer, width)
+		if err == nil {
+			m.DiffLines = strings.Split(diff, "\n")
+			m.DiffHighlights = nil
+			m.DiffViewOffset = min(m.DiffViewOffset, max(0, len(m.DiffLines)-1))
+			return m, nil
+		}
+		// fall back to the built-in renderer so the diff can still be read
+		m.DiffBuiltin = true
+		m.Message = fmt.Sprintf("⚠ External diff renderer failed, using the built-in one: %s", err)
+		m.MessageType = "warning"
+	}
+
+	diff, err := git.GetFileDiff(file.Name, file.Staged, m.DiffOptions)
+	if err != nil {
+		return m, err
+	}
+	return setDiffLines(m, diff), nil
+}
+
+// setDiffLines shows diff, highlighted unless highlighting is off.
+func setDiffLines(m model.Model, diff string) model.Model {
+	m.DiffLines = strings.Split(diff, "\n")
+	m.DiffHighlights = nil
+	if !m.DiffPlain {
+		m.DiffHighlights = syntax.HighlightDiff(m.DiffLines)
+	}
+	m.DiffViewOffset = min(m.DiffViewOffset, max(0, len(m.DiffLines)-1))
+	return m
+}
+
+// HandleDiffKey handles key messages when in the DiffView
+func HandleDiffKey(m model.Model, msg tea.KeyMsg) (model.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		return closeDiff(m), nil
+
+	case "H":
+		if m.DiffRev != "" {
+			if m.DiffBack == model.HistoryView {
+				return closeDiff(m), nil
+			}
+			return m, nil
+		}
+		return openFileHistory(m, model.DiffView), nil
+
+	case "up", "k":
+		if m.DiffViewOffset > 0 {
+			m.DiffViewOffset--
+		}
+		return m, nil
+
+	case "down", "j":
+		if m.DiffViewOffset < len(m.DiffLines)-1 {
+			m.DiffViewOffset++
+		}
+		return m, nil
+
+	case "v":
+		m.DiffSplit = !m.DiffSplit
+		return m, nil
+
+	case "h":
+		m.DiffPlain = !m.DiffPlain
+		m.DiffHighlights = nil
+		if !m.DiffPlain {
+			m.DiffHighlights = syntax.HighlightDiff(m.DiffLines)
+		}
+		return m, nil
+
+	case "e":
+		if !m.DiffRenderer.Enabled() {
+			m.Message = "⚠ No external diff renderer configured (git.diffpager or diff.external)"
+			m.MessageType = "warning"
+			return m, nil
+		}
+		m.DiffBuiltin = !m.DiffBuiltin
+		return reloadDiff(m), nil
+
+	case "t":
+		if m.DiffRev != "" {
+			return m, nil
+		}
+		return OpenExternalTool(m)
+
+	case "w":
+		m.DiffOptions.IgnoreWhitespace = !m.DiffOptions.IgnoreWhitespace
+		return reloadDiff(m), nil
+
+	case "+", "=":
+		if m.DiffOptions.ContextLines() < maxDiffContext {
+			m.DiffOptions.Context = m.DiffOptions.ContextLines() + 1
+			return reloadDiff(m), nil
+		}
+		return m, nil
+
+	case "-":
+		if m.DiffOptions.ContextLines() > 1 {
+			m.DiffOptions.Context = m.DiffOptions.ContextLines() - 1
+			return reloadDiff(m), nil
+		}
+		return m, nil
+
+	default:
+		return m, nil
+	}
+}
+
+// reloadDiff regenerates the diff after its options changed.
+func reloadDiff(m model.Model) model.Model {
+	m, err := loadFileDiff(m)
+	if err != nil {
+		m.Message = fmt.Sprintf("✗ Error getting diff: %s", err)
+		m.MessageType = "error"
+	}
+	return m
+}
+
+// OpenExternalTool suspends the TUI and opens the file under the cursor in
+// git's mergetool when it has conflicts, or in its difftool otherwise.
+func OpenExternalTool(m model.Model) (model.Model, tea.Cmd) {
+	if m.Cursor >= len(m.Files) {
+		m.Message = "⚠ No file selected or no files available"
+		m.MessageType = "warning"
+		return m, nil
+	}
+	file := m.Files[m.Cursor]
+	if git.IsConflictStatus(file.Status) {
+		return m, async.RunExternalTool("mergetool", file.Name, git.MergetoolCommand(file.Name))
+	}
+	return m, async.RunExternalTool("difftool", file.Name, git.DifftoolCommand(file.Name, file.Staged))
+}
+
+// HandleExternalToolResult refreshes what the tool may have changed once the
+// TUI resumes.
+func HandleExternalToolResult(m model.Model, msg async.ExternalToolMsg) model.Model {
+	// DiffView follows the file once the list is loaded, see followDiffFile
+	m.Refresh(model.RefreshFiles)
+	if msg.Err != nil {
+		m.Message = fmt.Sprintf("✗ %s failed for %s: %s", msg.Tool, msg.File, msg.Err)
+		m.MessageType = "error"
+	} else {
+		m.Message = fmt.Sprintf("✓ %s closed for %s", msg.Tool, msg.File)
+		m.MessageType = "success"
+	}
+
+	if m.CurrentView == model.BlameView && m.Blame.Rev == "" {
+		// the edit changed who last touched the lines
+		reloaded, err := loadBlame(m, m.Blame, m.BlameCursor)
+		if err == nil {
+			m = reloaded
+		}
+	}
+
+	return m
+}
+
+// shownDiffFile returns the file whose changes DiffView shows, or "" when
+// it shows none or a commit's.
+func shownDiffFile(m model.Model) string {
+	if m.CurrentView != model.DiffView || m.DiffRev != "" || m.Cursor >= len(m.Files) {
+		return ""
+	}
+	return m.Files[m.Cursor].Name
+}
+
+// followDiffFile keeps DiffView on the file name once the changed files were
+// loaded again: the file may have moved in the list, or have no changes left,
+// which closes the diff.
+func followDiffFile(m model.Model, name string) model.Model {
+	for i, file := range m.Files {
+		if file.Name == name {
+			m.Cursor = i
+			return reloadDiff(m)
+		}
+	}
+	return closeDiff(m)
+}
+
+// OpenEditor suspends the TUI and opens the file under the cursor in the
+// editor: at the hunk shown on top in DiffView, at the selected line in
+// BlameView, or at the first conflict marker of a conflicted file, also
+// from the conflicts listed by MergeView and RebaseView.
+func OpenEditor(m model.Model, template string) (model.Model, tea.Cmd) {
+	root := git.RepoRoot()
+
+	var name string
+	line := 0
+	switch m.CurrentView {
+	case model.MergeView, model.RebaseView:
+		if m.ConflictCursor >= len(m.LogLines) {
+			m.Message = "⚠ No conflicted file selected"
+			m.MessageType = "warning"
+			return m, nil
+		}
+		name = m.LogLines[m.ConflictCursor]
+		line = utils.ConflictLine(filepath.Join(root, name))
+	case model.BlameView:
+		if m.Blame.Rev != "" {
+			m.Message = "⚠ Blaming an older revision, [esc] back to the current file to edit it"
+			m.MessageType = "warning"
+			return m, nil
+		}
+		name = m.Blame.Path
+		line = m.BlameCursor + 1
+	default:
+		if m.Cursor >= len(m.Files) {
+			m.Message = "⚠ No file selected or no files available"
+			m.MessageType = "warning"
+			return m, nil
+		}
+		file := m.Files[m.Cursor]
+		name = file.Name
+		switch {
+		case m.CurrentView == model.DiffView:
+			line = utils.DiffLineNumber(m.DiffLines, m.DiffViewOffset)
+		case git.IsConflictStatus(file.Status):
+			line = utils.ConflictLine(filepath.Join(root, file.Name))
+		}
+	}
+
+	cmd := editor.Command(template, name, line)
+	cmd.Dir = root
+	return m, async.RunExternalTool("editor", name, cmd)
+}
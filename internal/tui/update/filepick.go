@@ -0,0 +1,79 @@
+package update
+
+import (
+	"fmt"
+
+	"froggit/internal/git"
+	"froggit/internal/tui/model"
+	"froggit/internal/utils"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// OpenFilePickView starts picking any tracked file, clean or changed, to
+// open in view, which is BlameView or HistoryView.
+func OpenFilePickView(m model.Model, view model.View) model.Model {
+	files, err := git.TrackedFiles()
+	if err != nil {
+		m.Message = fmt.Sprintf("✗ %s", err)
+		m.MessageType = "error"
+		return m
+	}
+	if len(files) == 0 {
+		m.Message = "⚠ No tracked files yet"
+		m.MessageType = "warning"
+		return m
+	}
+
+	m.FilePickList = files
+	m.FilePickInput = ""
+	m.FilePickCursor = 0
+	m.FilePickFor = view
+	m.CurrentView = model.FilePickView
+	m.Message = ""
+	return m
+}
+
+// HandleFilePickKey handles key messages in FilePickView. Typing narrows the
+// tracked files down to those whose path contains the text.
+func HandleFilePickKey(m model.Model, msg tea.KeyMsg) (model.Model, tea.Cmd) {
+	matches := m.FilePickMatches()
+	last := len(matches) - 1
+	switch msg.String() {
+	case "esc":
+		m.FilePickList = nil
+		m.CurrentView = model.FileView
+		m.Message = ""
+	case "up":
+		m.FilePickCursor = max(0, m.FilePickCursor-1)
+	case "down":
+		m.FilePickCursor = max(0, min(last, m.FilePickCursor+1))
+	case "pgup":
+		m.FilePickCursor = max(0, m.FilePickCursor-blamePage)
+	case "pgdown":
+		m.FilePickCursor = max(0, min(last, m.FilePickCursor+blamePage))
+	case "backspace":
+		if len(m.FilePickInput) > 0 {
+			runes := []rune(m.FilePickInput)
+			m.FilePickInput = string(runes[:len(runes)-1])
+			m.FilePickCursor = 0
+		}
+	case "enter":
+		if m.FilePickCursor > last {
+			return m, nil
+		}
+		path := matches[m.FilePickCursor]
+		m.FilePickList = nil
+		m.CurrentView = model.FileView
+		if m.FilePickFor == model.HistoryView {
+			return OpenHistoryView(m, path, model.FileView, ""), nil
+		}
+		return openBlame(m, path), nil
+	default:
+		if len(msg.Runes) == 1 && utils.IsPrintableChar(msg.Runes[0]) {
+			m.FilePickInput += string(msg.Runes)
+			m.FilePickCursor = 0
+		}
+	}
+	return m, nil
+}
@@ -0,0 +1,104 @@
+package update
+
+import (
+	"fmt"
+
+	"froggit/internal/git"
+	"froggit/internal/tui/model"
+	"froggit/internal/tui/update/handlers"
+	"froggit/internal/utils"
+)
+
+// ToggleFileTree switches FileView between the flat list and the directory
+// tree, keeping the file under the cursor selected.
+func ToggleFileTree(m model.Model) model.Model {
+	m.FileTree = !m.FileTree
+	m.TreeDir = ""
+	utils.EnsureFileVisible(&m)
+	if m.FileTree {
+		m.Message = "Showing files as a tree, [enter] collapses a directory"
+	} else {
+		m.Message = "Showing files as a list"
+	}
+	m.MessageType = "info"
+	return m
+}
+
+// HandleTreeDirKey handles the FileView keys that act on the directory
+// selected in the tree: staging, unstaging and discarding apply to every
+// changed file below it, and ignoring to the directory itself. It reports
+// false when no directory is selected or the key has no directory action.
+func HandleTreeDirKey(m model.Model, key string) (model.Model, bool) {
+	dir, ok := utils.SelectedDir(&m)
+	if !ok {
+		return m, false
+	}
+
+	switch key {
+	case "enter":
+		if m.CollapsedDirs == nil {
+			m.CollapsedDirs = make(map[string]bool)
+		}
+		m.CollapsedDirs[dir] = !m.CollapsedDirs[dir]
+		utils.EnsureFileVisible(&m)
+	case " ":
+		m = toggleDirStaged(m, dir)
+	case "x":
+		m.DialogType = "discard_dir"
+		m.DialogTarget = dir
+		m.CurrentView = model.ConfirmDialog
+	case "i":
+		m = handlers.OpenIgnoreView(m, dir+"/")
+	case "d", "o", "t", "B", "H":
+		m.Message = "⚠ Select a file inside the directory first"
+		m.MessageType = "warning"
+	default:
+		return m, false
+	}
+	return m, true
+}
+
+// toggleDirStaged stages every file below dir, or unstages them when all of
+// them are staged already.
+func toggleDirStaged(m model.Model, dir string) model.Model {
+	names := utils.FilesIn(&m, dir)
+	unstaged := 0
+	for _, file := range m.Files {
+		if !file.Staged && utils.InDir(file.Name, dir) {
+			unstaged++
+		}
+	}
+
+	stage := unstaged > 0
+	count := len(names)
+	if stage {
+		count = unstaged
+	}
+	m, _ = stagePaths(m, names, stage, fmt.Sprintf("%d files in %s/", count, dir))
+	return m
+}
+
+// DiscardDir discards the changes of every file below the directory of the
+// confirmation dialog.
+func DiscardDir(m model.Model) model.Model {
+	dir := m.DialogTarget
+	failed := 0
+	var lastErr error
+	for _, name := range utils.FilesIn(&m, dir) {
+		if err := git.DiscardChanges(name); err != nil {
+			failed++
+			lastErr = err
+		}
+	}
+
+	if failed > 0 {
+		m.Message = fmt.Sprintf("✗ Error discarding %d files in %s/: %s", failed, dir, lastErr)
+		m.MessageType = "error"
+	} else {
+		m.Message = fmt.Sprintf("✓ Changes discarded in %s/", dir)
+		m.MessageType = "success"
+	}
+	m.TreeDir = ""
+	m.Refresh(model.RefreshFiles)
+	return m
+}
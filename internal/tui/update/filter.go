@@ -0,0 +1,79 @@
+package update
+
+import (
+	"froggit/internal/tui/model"
+	"froggit/internal/utils"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// HandleFilterKey handles the "/" filter mode shared by the list views. It
+// returns false when the key should be processed by the view itself.
+func HandleFilterKey(m model.Model, msg tea.KeyMsg) (model.Model, bool) {
+	// a filter only lives as long as the view it was typed in
+	if m.FilterView != m.CurrentView {
+		m = clearFilter(m)
+	}
+	if !utils.IsFilterable(m.CurrentView) {
+		return m, false
+	}
+	// keys typed into a search of the view belong to the search
+	if m.SearchTyping && m.SearchView == m.CurrentView {
+		return m, false
+	}
+
+	if m.FilterTyping && m.FilterView == m.CurrentView {
+		switch msg.String() {
+		case "esc":
+			m = clearFilter(m)
+		case "enter":
+			m.FilterTyping = false
+			if m.Filter == "" {
+				m = clearFilter(m)
+			}
+		case "backspace":
+			if len(m.Filter) > 0 {
+				runes := []rune(m.Filter)
+				m.Filter = string(runes[:len(runes)-1])
+			}
+		case "up":
+			utils.MoveCursor(&m, -1)
+		case "down":
+			utils.MoveCursor(&m, 1)
+		default:
+			if len(msg.Runes) == 1 && utils.IsPrintableChar(msg.Runes[0]) {
+				m.Filter += string(msg.Runes)
+			}
+		}
+		utils.ValidateCursor(&m)
+		return m, true
+	}
+
+	switch msg.String() {
+	case "/":
+		m.Filter = ""
+		m.FilterView = m.CurrentView
+		m.FilterTyping = true
+		return m, true
+	case "esc":
+		if m.FilterActive() {
+			m = clearFilter(m)
+			utils.ValidateCursor(&m)
+			return m, true
+		}
+	case "ctrl+c", "q":
+		return m, false
+	}
+
+	// nothing matches: the cursor points at a hidden item, so don't act on it
+	if m.FilterActive() && len(utils.VisibleIndices(&m)) == 0 {
+		return m, true
+	}
+	return m, false
+}
+
+func clearFilter(m model.Model) model.Model {
+	m.Filter = ""
+	m.FilterTyping = false
+	return m
+}
@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"froggit/internal/tui/model"
+	"froggit/internal/tui/update/async"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// OpenAskpassPrompt shows the credential dialog for a prompt relayed by git or ssh.
+func OpenAskpassPrompt(m model.Model, msg async.AskpassPromptMsg) (model.Model, tea.Cmd) {
+	if m.CurrentView != model.AskpassView {
+		m.AskpassReturnView = m.CurrentView
+	}
+	m.AskpassRequest = msg.Request
+	m.AskpassInput = ""
+	m.CurrentView = model.AskpassView
+	return m, nil
+}
+
+// HandleAskpassView processes key messages in the credential prompt dialog.
+func HandleAskpassView(m model.Model, msg tea.KeyMsg) (model.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEnter:
+		if m.AskpassRequest != nil {
+			m.AskpassRequest.Answer(m.AskpassInput)
+		}
+		return closeAskpass(m), async.WaitForAskpass(m.Askpass)
+
+	case tea.KeyEsc:
+		if m.AskpassRequest != nil {
+			m.AskpassRequest.Cancel()
+		}
+		m = closeAskpass(m)
+		m.Message = "✗ Authentication cancelled"
+		m.MessageType = "error"
+		return m, async.WaitForAskpass(m.Askpass)
+
+	case tea.KeyCtrlC:
+		if m.AskpassRequest != nil {
+			m.AskpassRequest.Cancel()
+		}
+		return closeAskpass(m), tea.Quit
+
+	case tea.KeyBackspace:
+		if len(m.AskpassInput) > 0 {
+			runes := []rune(m.AskpassInput)
+			m.AskpassInput = string(runes[:len(runes)-1])
+		}
+		return m, nil
+
+	case tea.KeyRunes, tea.KeySpace:
+		m.AskpassInput += string(msg.Runes)
+		return m, nil
+	}
+	return m, nil
+}
+
+func closeAskpass(m model.Model) model.Model {
+	m.AskpassRequest = nil
+	m.AskpassInput = ""
+	m.CurrentView = m.AskpassReturnView
+	return m
+}
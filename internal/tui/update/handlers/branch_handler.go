@@ -0,0 +1,373 @@
+package handlers
+
+import (
+	"fmt"
+	"froggit/internal/git"
+	"froggit/internal/tui/model"
+	"froggit/internal/tui/update/async"
+	"froggit/internal/utils"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// OpenSetUpstreamView starts editing the upstream of the branch under the cursor.
+func OpenSetUpstreamView(m model.Model) model.Model {
+	if m.Cursor >= len(m.Branches) {
+		return m
+	}
+	m.UpstreamBranch = m.Branches[m.Cursor]
+	m.UpstreamInput = ""
+	if info, ok := m.BranchInfo(m.Cursor); ok && info.Upstream != "" {
+		m.UpstreamInput = info.Upstream
+	} else if remote := git.DefaultPushRemote(m.UpstreamBranch); remote != "" {
+		m.UpstreamInput = remote + "/" + m.UpstreamBranch
+	}
+	m.CurrentView = model.SetUpstreamView
+	m.Message = ""
+	return m
+}
+
+// HandleSetUpstreamView processes key messages while editing a branch upstream.
+func HandleSetUpstreamView(m model.Model, msg tea.KeyMsg) (model.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		if m.UpstreamInput == "" {
+			return m, nil
+		}
+		if err := git.SetUpstream(m.UpstreamBranch, m.UpstreamInput); err != nil {
+			m.Message = fmt.Sprintf("✗ Error setting upstream: %s", err)
+			m.MessageType = "error"
+			return m, nil
+		}
+		m.Message = fmt.Sprintf("✓ %s now tracks %s", m.UpstreamBranch, m.UpstreamInput)
+		m.MessageType = "success"
+		m.CurrentView = model.BranchView
+		m.Refresh(model.RefreshBranches)
+		return m, nil
+
+	case "esc":
+		m.CurrentView = model.BranchView
+		m.Message = ""
+		return m, nil
+
+	case "backspace":
+		if len(m.UpstreamInput) > 0 {
+			m.UpstreamInput = m.UpstreamInput[:len(m.UpstreamInput)-1]
+		}
+		return m, nil
+	}
+
+	if len(msg.Runes) == 1 && utils.IsPrintableChar(msg.Runes[0]) && msg.Runes[0] != ' ' {
+		m.UpstreamInput += string(msg.Runes)
+	}
+	return m, nil
+}
+
+// UnsetUpstream removes the upstream of the branch under the cursor.
+func UnsetUpstream(m model.Model) model.Model {
+	info, ok := m.BranchInfo(m.Cursor)
+	if !ok {
+		return m
+	}
+	if info.Upstream == "" {
+		m.Message = fmt.Sprintf("⚠ %s has no upstream", info.Name)
+		m.MessageType = "warning"
+		return m
+	}
+	if err := git.UnsetUpstream(info.Name); err != nil {
+		m.Message = fmt.Sprintf("✗ Error unsetting upstream: %s", err)
+		m.MessageType = "error"
+		return m
+	}
+	m.Message = fmt.Sprintf("✓ %s no longer tracks %s", info.Name, info.Upstream)
+	m.MessageType = "success"
+	m.Refresh(model.RefreshBranches)
+	return m
+}
+
+// SelectedBranchRemote returns the remote to fetch for the row under the
+// cursor: the remote of a remote branch, or the upstream remote of a local one.
+func SelectedBranchRemote(m model.Model) string {
+	if remote, ok := m.SelectedRemoteBranch(); ok {
+		return remote.Remote
+	}
+	if m.Cursor < len(m.Branches) {
+		return git.DefaultPushRemote(m.Branches[m.Cursor])
+	}
+	return ""
+}
+
+// OpenRenameBranchView starts renaming the local branch under the cursor.
+func OpenRenameBranchView(m model.Model) model.Model {
+	if m.Cursor >= len(m.Branches) {
+		return m
+	}
+	m.RenameFrom = m.Branches[m.Cursor]
+	m.RenameInput = m.RenameFrom
+	m.RenameOnRemote = false
+	m.CurrentView = model.RenameBranchView
+	m.Message = ""
+	return m
+}
+
+// renameUpstream returns the remote and remote branch name the branch being
+// renamed tracks, if any.
+func renameUpstream(m model.Model) (string, string, bool) {
+	for _, info := range m.BranchInfos {
+		if info.Name == m.RenameFrom && info.Upstream != "" && !info.Gone {
+			remote, name, ok := strings.Cut(info.Upstream, "/")
+			return remote, name, ok
+		}
+	}
+	return "", "", false
+}
+
+// HandleRenameBranchView processes key messages while renaming a branch.
+func HandleRenameBranchView(m model.Model, msg tea.KeyMsg) (model.Model, tea.Cmd) {
+	switch msg.String() {
+	case "tab":
+		if _, _, ok := renameUpstream(m); ok {
+			m.RenameOnRemote = !m.RenameOnRemote
+		} else {
+			m.Message = "⚠ Branch has no upstream to rename"
+			m.MessageType = "warning"
+		}
+		return m, nil
+
+	case "enter":
+		if m.RenameInput == "" || m.RenameInput == m.RenameFrom {
+			return m, nil
+		}
+		if err := git.RenameBranch(m.RenameFrom, m.RenameInput); err != nil {
+			m.Message = fmt.Sprintf("✗ Error renaming branch: %s", err)
+			m.MessageType = "error"
+			return m, nil
+		}
+		if m.CurrentBranch == m.RenameFrom {
+			m.CurrentBranch = m.RenameInput
+		}
+		m.CurrentView = model.BranchView
+		m.Message = fmt.Sprintf("✓ Renamed %s to %s", m.RenameFrom, m.RenameInput)
+		m.MessageType = "success"
+
+		var cmd tea.Cmd
+		if remote, remoteName, ok := renameUpstream(m); ok && m.RenameOnRemote {
+			m.Message = fmt.Sprintf("Renamed locally, renaming %s/%s to %s...", remote, remoteName, m.RenameInput)
+			m.MessageType = "info"
+			cmd = async.PerformRenameRemoteBranch(remote, remoteName, m.RenameInput)
+		}
+		m.Refresh(model.RefreshBranches)
+		return m, cmd
+
+	case "esc":
+		m.CurrentView = model.BranchView
+		m.Message = ""
+		return m, nil
+
+	case "backspace":
+		if len(m.RenameInput) > 0 {
+			m.RenameInput = m.RenameInput[:len(m.RenameInput)-1]
+		}
+		return m, nil
+	}
+
+	if len(msg.Runes) == 1 && utils.IsPrintableChar(msg.Runes[0]) && msg.Runes[0] != ' ' {
+		m.RenameInput += string(msg.Runes)
+	}
+	return m, nil
+}
+
+// RequestDeleteBranch asks for confirmation before deleting the local branch
+// under the cursor. Branches not merged into HEAD, or forced deletes, need the
+// branch name typed as a stronger confirmation.
+func RequestDeleteBranch(m model.Model, force bool) model.Model {
+	if m.Cursor >= len(m.Branches) {
+		return m
+	}
+	name := m.Branches[m.Cursor]
+	if name == m.CurrentBranch {
+		m.Message = "✗ Cannot delete current branch"
+		m.MessageType = "error"
+		return m
+	}
+
+	m.DialogTarget = name
+	m.DialogType = "delete_branch"
+	if force || !git.IsBranchMerged(name, "HEAD") {
+		m.DialogType = "force_delete_branch"
+		m.ConfirmInput = ""
+	}
+	m.CurrentView = model.ConfirmDialog
+	return m
+}
+
+// HandleForceDeleteBranch processes the typed confirmation of a force delete.
+func HandleForceDeleteBranch(m model.Model, msg tea.KeyMsg) (model.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		if m.ConfirmInput != m.DialogTarget {
+			m.Message = "⚠ Type the branch name exactly to confirm"
+			m.MessageType = "warning"
+			return m, nil
+		}
+		if err := git.ForceDeleteBranch(m.DialogTarget); err != nil {
+			m.Message = fmt.Sprintf("✗ Error deleting branch: %s", err)
+			m.MessageType = "error"
+		} else {
+			m.Message = fmt.Sprintf("✓ Branch %s force deleted", m.DialogTarget)
+			m.MessageType = "success"
+			m.Refresh(model.RefreshBranches)
+		}
+		m.ConfirmInput = ""
+		m.CurrentView = model.BranchView
+		if len(m.CleanupForce) > 1 {
+			return nextCleanupForceDelete(m, m.CleanupForce[1:]), nil
+		}
+		m.CleanupForce, m.CleanupCandidates = nil, nil
+		utils.ValidateCursor(&m)
+		return m, nil
+
+	case "esc":
+		m.ConfirmInput = ""
+		m.CleanupForce, m.CleanupCandidates = nil, nil
+		m.CurrentView = model.BranchView
+		return m, nil
+
+	case "backspace":
+		if len(m.ConfirmInput) > 0 {
+			m.ConfirmInput = m.ConfirmInput[:len(m.ConfirmInput)-1]
+		}
+		return m, nil
+	}
+
+	if len(msg.Runes) == 1 && utils.IsPrintableChar(msg.Runes[0]) {
+		m.ConfirmInput += string(msg.Runes)
+	}
+	return m, nil
+}
+
+// OpenCleanupView lists branches merged into base or with a gone upstream.
+func OpenCleanupView(m model.Model, base string) model.Model {
+	candidates, err := git.FindCleanupCandidates(base)
+	if err != nil {
+		m.Message = fmt.Sprintf("✗ Error finding branches to clean up: %s", err)
+		m.MessageType = "error"
+		return m
+	}
+	if len(candidates) == 0 {
+		m.Message = fmt.Sprintf("✓ No branches merged into %s or with a gone upstream", base)
+		m.MessageType = "success"
+		return m
+	}
+	m.CleanupBase = base
+	m.CleanupCandidates = candidates
+	m.Cursor = 0
+	m.CurrentView = model.CleanupView
+	m.Message = ""
+	return m
+}
+
+// HandleCleanupView processes key messages while reviewing branches to delete.
+func HandleCleanupView(m model.Model, msg tea.KeyMsg) (model.Model, tea.Cmd) {
+	switch msg.String() {
+	case "up":
+		if m.Cursor > 0 {
+			m.Cursor--
+		}
+	case "down":
+		if m.Cursor < len(m.CleanupCandidates)-1 {
+			m.Cursor++
+		}
+	case " ":
+		if m.Cursor < len(m.CleanupCandidates) {
+			m.CleanupCandidates[m.Cursor].Selected = !m.CleanupCandidates[m.Cursor].Selected
+		}
+	case "a":
+		all := true
+		for _, c := range m.CleanupCandidates {
+			all = all && c.Selected
+		}
+		for i := range m.CleanupCandidates {
+			m.CleanupCandidates[i].Selected = !all
+		}
+	case "enter":
+		merged, unmerged := cleanupSelection(m)
+		if len(merged)+len(unmerged) == 0 {
+			m.Message = "⚠ No branches selected"
+			m.MessageType = "warning"
+			return m, nil
+		}
+		if len(merged) == 0 {
+			return nextCleanupForceDelete(m, unmerged), nil
+		}
+		m.DialogType = "cleanup_branches"
+		m.DialogTarget = fmt.Sprintf("%d merged branches", len(merged))
+		m.CurrentView = model.ConfirmDialog
+	case "esc":
+		m.CleanupCandidates = nil
+		m.Cursor = 0
+		m.CurrentView = model.BranchView
+		m.Message = ""
+	}
+	return m, nil
+}
+
+// cleanupSelection splits the selected cleanup candidates into the branches
+// merged into the base and the unmerged ones.
+func cleanupSelection(m model.Model) (merged, unmerged []string) {
+	for _, c := range m.CleanupCandidates {
+		switch {
+		case !c.Selected:
+		case c.Merged:
+			merged = append(merged, c.Name)
+		default:
+			unmerged = append(unmerged, c.Name)
+		}
+	}
+	return merged, unmerged
+}
+
+// DeleteCleanupSelection deletes the selected branches merged into the base,
+// then asks for the typed force delete confirmation of each unmerged one.
+// The merged branches were checked against the base, which git branch -d
+// doesn't look at, so they are deleted with -D.
+func DeleteCleanupSelection(m model.Model) model.Model {
+	merged, unmerged := cleanupSelection(m)
+	deleted := 0
+	var failed []string
+	for _, name := range merged {
+		if err := git.ForceDeleteBranch(name); err != nil {
+			failed = append(failed, name)
+		} else {
+			deleted++
+		}
+	}
+
+	if len(failed) > 0 {
+		m.Message = fmt.Sprintf("✗ Deleted %d branches, failed: %s", deleted, strings.Join(failed, ", "))
+		m.MessageType = "error"
+	} else {
+		m.Message = fmt.Sprintf("✓ Deleted %d branches", deleted)
+		m.MessageType = "success"
+	}
+	m.Cursor = 0
+	m.CurrentView = model.BranchView
+	m.Refresh(model.RefreshBranches)
+	if len(unmerged) > 0 {
+		return nextCleanupForceDelete(m, unmerged)
+	}
+	m.CleanupCandidates = nil
+	return m
+}
+
+// nextCleanupForceDelete asks for the typed confirmation of the first of the
+// unmerged cleanup branches, the rest following once it is handled.
+func nextCleanupForceDelete(m model.Model, names []string) model.Model {
+	m.CleanupForce = names
+	m.DialogType = "force_delete_branch"
+	m.DialogTarget = names[0]
+	m.ConfirmInput = ""
+	m.CurrentView = model.ConfirmDialog
+	return m
+}
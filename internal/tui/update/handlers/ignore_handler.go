@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"fmt"
+	"froggit/internal/git"
+	"froggit/internal/tui/model"
+	"froggit/internal/utils"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// OpenIgnoreView starts ignoring an untracked file or directory.
+func OpenIgnoreView(m model.Model, path string) model.Model {
+	m.IgnorePath = path
+	m.IgnoreTargets = git.IgnoreTargets(path)
+	m.IgnoreTarget = 0
+	m.IgnorePattern = 0
+	m.IgnoreField = model.IgnoreFieldPattern
+	m.CurrentView = model.IgnoreView
+	m.Message = ""
+	m.MessageType = ""
+	return m
+}
+
+// HandleIgnoreView processes key messages in the ignore dialog.
+func HandleIgnoreView(m model.Model, msg tea.KeyMsg) (model.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.CurrentView = model.FileView
+		m.Message = ""
+		m.MessageType = ""
+		return m, nil
+
+	case "up", "down", "tab", "shift+tab":
+		m.IgnoreField = (m.IgnoreField + 1) % model.IgnoreFieldCount
+		return m, nil
+
+	case "left", "right":
+		step := 1
+		if msg.String() == "left" {
+			step = -1
+		}
+		if m.IgnoreField == model.IgnoreFieldPattern {
+			n := len(m.IgnorePatterns())
+			m.IgnorePattern = (m.IgnorePattern + step + n) % n
+		} else if n := len(m.IgnoreTargets); n > 0 {
+			m.IgnoreTarget = (m.IgnoreTarget + step + n) % n
+			// a nested file offers fewer patterns
+			m.IgnorePattern = min(m.IgnorePattern, len(m.IgnorePatterns())-1)
+		}
+		return m, nil
+
+	case "enter":
+		if m.IgnoreTarget >= len(m.IgnoreTargets) {
+			return m, nil
+		}
+		target := m.IgnoreTargets[m.IgnoreTarget]
+		pattern := m.IgnorePatterns()[m.IgnorePattern]
+		added, err := git.AddIgnorePatterns(target.File, []string{pattern})
+		if err != nil {
+			m.Message = fmt.Sprintf("✗ %s", err)
+			m.MessageType = "error"
+			return m, nil
+		}
+
+		m.CurrentView = model.FileView
+		if added == 0 {
+			m.Message = fmt.Sprintf("⚠ %s already has %s", target.Label, pattern)
+			m.MessageType = "warning"
+		} else {
+			m.Message = fmt.Sprintf("✓ Added %s to %s", pattern, target.Label)
+			m.MessageType = "success"
+		}
+		m.Refresh(model.RefreshFiles)
+		return m, nil
+
+	case "I":
+		return OpenIgnoreCheckView(m, m.IgnorePath), nil
+	}
+	return m, nil
+}
+
+// OpenIgnoreCheckView starts explaining whether path is ignored.
+func OpenIgnoreCheckView(m model.Model, path string) model.Model {
+	m.IgnoreCheck = path
+	m.CurrentView = model.IgnoreCheckView
+	m.Message = ""
+	m.MessageType = ""
+	return checkIgnore(m)
+}
+
+// HandleIgnoreCheckView processes key messages while a path is typed in the
+// ignore explanation view, explaining it again after every change.
+func HandleIgnoreCheckView(m model.Model, msg tea.KeyMsg) (model.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "enter":
+		m.CurrentView = model.FileView
+		m.Message = ""
+		m.MessageType = ""
+		return m, nil
+
+	case "backspace":
+		if len(m.IgnoreCheck) > 0 {
+			m.IgnoreCheck = m.IgnoreCheck[:len(m.IgnoreCheck)-1]
+			return checkIgnore(m), nil
+		}
+		return m, nil
+	}
+
+	if len(msg.Runes) == 1 && utils.IsPrintableChar(msg.Runes[0]) {
+		m.IgnoreCheck += string(msg.Runes)
+		return checkIgnore(m), nil
+	}
+	return m, nil
+}
+
+func checkIgnore(m model.Model) model.Model {
+	m.IgnoreMatch = git.IgnoreMatch{}
+	m.Message = ""
+	if m.IgnoreCheck == "" {
+		return m
+	}
+	match, err := git.ExplainIgnore(m.IgnoreCheck)
+	if err != nil {
+		m.Message = fmt.Sprintf("✗ %s", err)
+		m.MessageType = "error"
+		return m
+	}
+	m.IgnoreMatch = match
+	return m
+}
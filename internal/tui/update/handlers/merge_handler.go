@@ -16,12 +16,16 @@ func HandleMergeView(m model.Model, msg tea.KeyMsg) (model.Model, tea.Cmd) {
 
 	switch msg.String() {
 	case "up":
-		if m.Cursor > 0 {
+		if len(m.LogLines) > 0 {
+			m.ConflictCursor = max(0, m.ConflictCursor-1)
+		} else if m.Cursor > 0 {
 			m.Cursor--
 		}
 		return m, nil
 	case "down":
-		if m.Cursor < len(m.Branches)-1 {
+		if len(m.LogLines) > 0 {
+			m.ConflictCursor = min(len(m.LogLines)-1, m.ConflictCursor+1)
+		} else if m.Cursor < len(m.Branches)-1 {
 			m.Cursor++
 		}
 		return m, nil
@@ -78,6 +82,7 @@ func HandleMergeView(m model.Model, msg tea.KeyMsg) (model.Model, tea.Cmd) {
 			conflicts, _ := git.GetConflictFiles()
 			if len(conflicts) > 0 {
 				m.LogLines = conflicts
+				m.ConflictCursor = 0
 				m.Message = "Conflicts still present. Please resolve all conflicts."
 				m.MessageType = "warning"
 				return m, nil
@@ -100,7 +105,7 @@ func HandleMergeView(m model.Model, msg tea.KeyMsg) (model.Model, tea.Cmd) {
 			m.CurrentView = model.FileView
 			m.DialogTarget = ""
 			m.LogLines = nil
-			m.RefreshData()
+			m.Refresh(model.RefreshHead)
 			return m, nil
 		}
 	case "esc":
@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"froggit/internal/git"
+	"froggit/internal/tui/model"
+	"froggit/internal/tui/update/async"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// OpenPullView shows the pull strategy chooser with the given ahead/behind counts.
+func OpenPullView(m model.Model, ahead, behind int, strategy string) model.Model {
+	m.PullAhead = ahead
+	m.PullBehind = behind
+	m.PullCursor = 0
+	for i, s := range git.PullStrategies {
+		if s == strategy {
+			m.PullCursor = i
+		}
+	}
+	m.CurrentView = model.PullView
+	return m
+}
+
+// HandlePullView processes key messages in the pull strategy chooser.
+func HandlePullView(m model.Model, msg tea.KeyMsg) (model.Model, tea.Cmd) {
+	switch msg.String() {
+	case "up":
+		if m.PullCursor > 0 {
+			m.PullCursor--
+		}
+		return m, nil
+	case "down":
+		if m.PullCursor < len(git.PullStrategies)-1 {
+			m.PullCursor++
+		}
+		return m, nil
+	case "enter":
+		if m.IsPulling {
+			return m, nil
+		}
+		strategy := git.PullStrategies[m.PullCursor]
+		m.IsPulling = true
+		m.CurrentView = model.FileView
+		m.Message = fmt.Sprintf("Pulling (%s)...", strategy)
+		m.MessageType = "info"
+		return m, tea.Batch(async.PerformPull(strategy), async.Spinner())
+	case "esc":
+		m.CurrentView = model.FileView
+		m.Message = ""
+		return m, nil
+	}
+	return m, nil
+}
+
+// HandlePullResult applies the outcome of a pull. Divergence opens the strategy
+// chooser and conflicts are routed into the merge or rebase conflict flow.
+func HandlePullResult(m model.Model, msg async.PullMsg) model.Model {
+	m.IsPulling = false
+
+	switch {
+	case errors.Is(msg.Err, git.ErrDiverged):
+		m = OpenPullView(m, msg.Result.Ahead, msg.Result.Behind, msg.Strategy)
+		m.Message = fmt.Sprintf("⚠ Branch has diverged: %d local and %d remote commits. Choose how to pull.", msg.Result.Ahead, msg.Result.Behind)
+		m.MessageType = "warning"
+
+	case len(msg.Result.Conflicts) > 0:
+		m.LogLines = msg.Result.Conflicts
+		m.ConflictCursor = 0
+		m.Cursor = 0
+		m.DialogTarget = ""
+		if msg.Strategy == git.PullRebase || msg.Strategy == git.PullAutostash || git.IsRebaseInProgress() {
+			m.CurrentView = model.RebaseView
+			m.Message = "Conflicts detected while rebasing onto upstream. Please resolve them and use [P] Proceed or [X] Cancel."
+		} else {
+			m.CurrentView = model.MergeView
+			m.Message = "Conflicts detected while merging upstream. Please resolve them and use [P] Proceed or [X] Cancel."
+		}
+		m.MessageType = "warning"
+		m.Refresh(model.RefreshHead)
+
+	case msg.Err != nil:
+		m.Message = fmt.Sprintf("✗ Error pulling changes: %s", msg.Err)
+		m.MessageType = "error"
+
+	case msg.Result.Behind == 0:
+		m.Message = "✓ Already up to date"
+		m.MessageType = "success"
+		m.Refresh(model.RefreshBranches)
+
+	default:
+		m.Message = "✓ Changes pulled successfully"
+		m.MessageType = "success"
+		m.Refresh(model.RefreshHead)
+	}
+	return m
+}
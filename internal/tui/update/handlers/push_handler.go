@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"fmt"
+	"froggit/internal/git"
+	"froggit/internal/tui/model"
+	"froggit/internal/tui/update/async"
+	"froggit/internal/utils"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// OpenPushView prepares the push dialog for the current branch.
+func OpenPushView(m model.Model) model.Model {
+	m.PushRemotes = git.GetRemoteNames()
+	if len(m.PushRemotes) == 0 {
+		m.Message = "⚠ No remotes configured"
+		m.MessageType = "warning"
+		return m
+	}
+
+	m.PushRemote = git.DefaultPushRemote(m.CurrentBranch)
+	if m.PushRemote == "" {
+		m.PushRemote = m.PushRemotes[0]
+	}
+	m.PushBranch = m.CurrentBranch
+	m.PushForce = false
+	m.PushTags = false
+	m.PushDryRun = false
+	m.PushField = model.PushFieldRemote
+	m.PushRemoteSHA = git.RemoteBranchSHA(m.PushRemote, m.PushBranch)
+	m.CurrentView = model.PushView
+	m.Message = ""
+	return m
+}
+
+// HandlePushView processes key messages in the push dialog.
+func HandlePushView(m model.Model, msg tea.KeyMsg) (model.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.CurrentView = model.FileView
+		m.Message = ""
+		return m, nil
+
+	case "up", "shift+tab":
+		m.PushField = (m.PushField + model.PushFieldCount - 1) % model.PushFieldCount
+		return m, nil
+
+	case "down", "tab":
+		m.PushField = (m.PushField + 1) % model.PushFieldCount
+		return m, nil
+
+	case "left", "right":
+		if m.PushField == model.PushFieldRemote && len(m.PushRemotes) > 0 {
+			step := 1
+			if msg.String() == "left" {
+				step = len(m.PushRemotes) - 1
+			}
+			idx := 0
+			for i, r := range m.PushRemotes {
+				if r == m.PushRemote {
+					idx = i
+				}
+			}
+			m.PushRemote = m.PushRemotes[(idx+step)%len(m.PushRemotes)]
+			m.PushRemoteSHA = git.RemoteBranchSHA(m.PushRemote, m.PushBranch)
+		}
+		return m, nil
+
+	case " ":
+		switch m.PushField {
+		case model.PushFieldForce:
+			m.PushForce = !m.PushForce
+			return m, nil
+		case model.PushFieldTags:
+			m.PushTags = !m.PushTags
+			return m, nil
+		case model.PushFieldDryRun:
+			m.PushDryRun = !m.PushDryRun
+			return m, nil
+		}
+
+	case "backspace":
+		if m.PushField == model.PushFieldBranch && len(m.PushBranch) > 0 {
+			m.PushBranch = m.PushBranch[:len(m.PushBranch)-1]
+			m.PushRemoteSHA = git.RemoteBranchSHA(m.PushRemote, m.PushBranch)
+		}
+		return m, nil
+
+	case "enter":
+		if m.PushBranch == "" {
+			m.Message = "⚠ Enter a target branch name"
+			m.MessageType = "warning"
+			return m, nil
+		}
+		if m.IsPushing {
+			return m, nil
+		}
+		opts := git.PushOptions{
+			Remote:         m.PushRemote,
+			Branch:         m.PushBranch,
+			ForceWithLease: m.PushForce,
+			ExpectedSHA:    m.PushRemoteSHA,
+			Tags:           m.PushTags,
+			DryRun:         m.PushDryRun,
+			SetUpstream:    !m.PushDryRun && !git.HasUpstream(),
+		}
+		m.IsPushing = true
+		m.CurrentView = model.FileView
+		if opts.DryRun {
+			m.Message = fmt.Sprintf("Dry run: pushing to %s/%s...", opts.Remote, opts.Branch)
+		} else {
+			m.Message = fmt.Sprintf("Pushing to %s/%s...", opts.Remote, opts.Branch)
+		}
+		m.MessageType = "info"
+		return m, tea.Batch(async.PerformPushWithOptions(opts), async.Spinner())
+	}
+
+	if m.PushField == model.PushFieldBranch && len(msg.Runes) == 1 && utils.IsPrintableChar(msg.Runes[0]) && msg.Runes[0] != ' ' {
+		m.PushBranch += string(msg.Runes)
+		m.PushRemoteSHA = git.RemoteBranchSHA(m.PushRemote, m.PushBranch)
+	}
+	return m, nil
+}
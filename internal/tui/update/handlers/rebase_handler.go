@@ -13,12 +13,16 @@ func HandleRebaseView(m model.Model, msg tea.KeyMsg) (model.Model, tea.Cmd) {
 	if m.CurrentView == model.RebaseView {
 		switch msg.String() {
 		case "up":
-			if m.Cursor > 0 {
+			if len(m.LogLines) > 0 {
+				m.ConflictCursor = max(0, m.ConflictCursor-1)
+			} else if m.Cursor > 0 {
 				m.Cursor--
 			}
 			return m, nil
 		case "down":
-			if m.Cursor < len(m.Branches)-1 {
+			if len(m.LogLines) > 0 {
+				m.ConflictCursor = min(len(m.LogLines)-1, m.ConflictCursor+1)
+			} else if m.Cursor < len(m.Branches)-1 {
 				m.Cursor++
 			}
 			return m, nil
@@ -61,6 +65,7 @@ func HandleRebaseView(m model.Model, msg tea.KeyMsg) (model.Model, tea.Cmd) {
 				conflicts, _ := git.GetConflictFiles()
 				if len(conflicts) > 0 {
 					m.LogLines = conflicts
+					m.ConflictCursor = 0
 					m.Message = "Conflicts detected. Please resolve them and use [P] Proceed or [X] Cancel."
 					m.MessageType = "warning"
 					return m, nil
@@ -70,7 +75,7 @@ func HandleRebaseView(m model.Model, msg tea.KeyMsg) (model.Model, tea.Cmd) {
 					m.CurrentView = model.FileView
 					m.DialogTarget = ""
 					m.LogLines = nil
-					m.RefreshData()
+					m.Refresh(model.RefreshHead)
 					return m, nil
 				}
 			} else {
@@ -87,8 +92,9 @@ func HandleRebaseView(m model.Model, msg tea.KeyMsg) (model.Model, tea.Cmd) {
 					return m, nil
 				}
 				conflicts, _ := git.GetConflictFiles()
-				if len(m.LogLines) > 0 {
+				if len(conflicts) > 0 {
 					m.LogLines = conflicts
+					m.ConflictCursor = 0
 					m.Message = "Conflicts still present. Please resolve all conflicts."
 					m.MessageType = "warning"
 					return m, nil
@@ -98,7 +104,7 @@ func HandleRebaseView(m model.Model, msg tea.KeyMsg) (model.Model, tea.Cmd) {
 				m.CurrentView = model.FileView
 				m.DialogTarget = ""
 				m.LogLines = nil
-				m.RefreshData()
+				m.Refresh(model.RefreshHead)
 				return m, nil
 			}
 		case "X", "x":
@@ -114,7 +120,7 @@ func HandleRebaseView(m model.Model, msg tea.KeyMsg) (model.Model, tea.Cmd) {
 				m.CurrentView = model.FileView
 				m.DialogTarget = ""
 				m.LogLines = nil
-				m.RefreshData()
+				m.Refresh(model.RefreshHead)
 				return m, nil
 			}
 		case "esc":
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"froggit/internal/git"
 	"froggit/internal/tui/model"
+	"froggit/internal/utils"
+	"slices"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -12,25 +14,17 @@ import (
 func HandleStashView(m model.Model, msg tea.KeyMsg) (model.Model, tea.Cmd) {
 	switch msg.String() {
 	case "up":
-		if m.Cursor > 0 {
-			m.Cursor--
-		}
+		utils.MoveCursor(&m, -1)
 		return m, nil
 
 	case "down":
-		if m.Cursor < len(m.Stashes)-1 {
-			m.Cursor++
-		}
+		utils.MoveCursor(&m, 1)
 		return m, nil
 
 	case "s", "S":
 		// Save stash - only if there are changes
 		if len(m.Files) > 0 {
-			m.CurrentView = model.StashMessageView
-			m.StashMessage = ""
-			m.Message = ""
-			m.MessageType = ""
-			return m, nil
+			return OpenStashMessageView(m, nil), nil
 		} else {
 			m.Message = "⚠ No changes to stash"
 			m.MessageType = "warning"
@@ -49,7 +43,7 @@ func HandleStashView(m model.Model, msg tea.KeyMsg) (model.Model, tea.Cmd) {
 			} else {
 				m.Message = fmt.Sprintf("✓ Stash %s applied successfully", stashRef)
 				m.MessageType = "success"
-				m.RefreshData()
+				m.Refresh(model.RefreshFiles | model.RefreshStashes)
 			}
 		}
 		return m, nil
@@ -60,13 +54,13 @@ func HandleStashView(m model.Model, msg tea.KeyMsg) (model.Model, tea.Cmd) {
 			selectedStash := m.Stashes[m.Cursor]
 			stashRef := git.GetStashRef(selectedStash)
 
-			if err := git.StashPop(); err != nil {
+			if err := git.StashPop(stashRef); err != nil {
 				m.Message = fmt.Sprintf("✗ Error popping stash: %s", err)
 				m.MessageType = "error"
 			} else {
 				m.Message = fmt.Sprintf("✓ Stash %s popped successfully", stashRef)
 				m.MessageType = "success"
-				m.RefreshData()
+				m.Refresh(model.RefreshFiles | model.RefreshStashes)
 			}
 		}
 		return m, nil
@@ -102,6 +96,21 @@ func HandleStashView(m model.Model, msg tea.KeyMsg) (model.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case "b", "r":
+		if len(m.Stashes) > 0 && m.Cursor < len(m.Stashes) {
+			m.StashRef = git.GetStashRef(m.Stashes[m.Cursor])
+			m.StashAction = "branch"
+			m.StashInput = ""
+			if msg.String() == "r" {
+				m.StashAction = "rename"
+				_, m.StashInput = splitStashMessage(m.Stashes[m.Cursor])
+			}
+			m.CurrentView = model.StashInputView
+			m.Message = ""
+			m.MessageType = ""
+		}
+		return m, nil
+
 	case "esc":
 		m.CurrentView = model.FileView
 		m.Message = ""
@@ -109,7 +118,7 @@ func HandleStashView(m model.Model, msg tea.KeyMsg) (model.Model, tea.Cmd) {
 		return m, nil
 
 	case "?":
-		m.Message = "Stash Help: [S]ave [Enter]Apply [P]op [D]rop [V]iew [↑/↓]Navigate [Esc]Back"
+		m.Message = "Stash Help: [S]ave [Enter]Apply [P]op [D]rop [V]iew [B]ranch [R]ename [↑/↓]Navigate [Esc]Back"
 		m.MessageType = "info"
 		return m, nil
 	}
@@ -117,8 +126,67 @@ func HandleStashView(m model.Model, msg tea.KeyMsg) (model.Model, tea.Cmd) {
 	return m, nil
 }
 
+// OpenStashMessageView starts saving a stash, of the given files only when
+// paths isn't empty.
+func OpenStashMessageView(m model.Model, paths []string) model.Model {
+	m.StashPaths = paths
+	m.StashMessage = ""
+	m.StashField = model.StashFieldMessage
+	m.StashStaged = false
+	m.StashKeepIndex = false
+	// git refuses to stash untracked paths without it
+	m.StashUntracked = false
+	for _, file := range m.Files {
+		if file.Status == "??" && slices.Contains(paths, file.Name) {
+			m.StashUntracked = true
+		}
+	}
+	m.CurrentView = model.StashMessageView
+	m.Message = ""
+	m.MessageType = ""
+	return m
+}
+
 func HandleStashMessageView(m model.Model, msg tea.KeyMsg) (model.Model, tea.Cmd) {
 	switch msg.String() {
+	case "up", "shift+tab":
+		m.StashField = (m.StashField + model.StashFieldCount - 1) % model.StashFieldCount
+		return m, nil
+
+	case "down", "tab":
+		m.StashField = (m.StashField + 1) % model.StashFieldCount
+		return m, nil
+
+	case " ":
+		switch m.StashField {
+		case model.StashFieldStaged:
+			// git stashes the index alone only for the whole worktree, and
+			// neither with untracked files nor keeping the index
+			if len(m.StashPaths) > 0 {
+				return m, nil
+			}
+			m.StashStaged = !m.StashStaged
+			if m.StashStaged {
+				m.StashKeepIndex = false
+				m.StashUntracked = false
+			}
+			return m, nil
+		case model.StashFieldKeepIndex:
+			m.StashKeepIndex = !m.StashKeepIndex
+			if m.StashKeepIndex {
+				m.StashStaged = false
+			}
+			return m, nil
+		case model.StashFieldUntracked:
+			m.StashUntracked = !m.StashUntracked
+			if m.StashUntracked {
+				m.StashStaged = false
+			}
+			return m, nil
+		}
+		m.StashMessage += " "
+		return m, nil
+
 	case "enter":
 		// Save stash with message
 		message := m.StashMessage
@@ -126,35 +194,144 @@ func HandleStashMessageView(m model.Model, msg tea.KeyMsg) (model.Model, tea.Cmd
 			message = "Work in progress"
 		}
 
-		if err := git.SaveStash(message); err != nil {
+		opts := git.StashOptions{
+			Message:          message,
+			Staged:           m.StashStaged,
+			KeepIndex:        m.StashKeepIndex,
+			IncludeUntracked: m.StashUntracked,
+			Paths:            m.StashPaths,
+		}
+		if err := git.StashPush(opts); err != nil {
 			m.Message = fmt.Sprintf("✗ Error saving stash: %s", err)
 			m.MessageType = "error"
+			return m, nil
+		}
+
+		m.MessageType = "success"
+		m.CurrentView = model.StashView
+		if len(m.StashPaths) > 0 {
+			m.Message = fmt.Sprintf("✓ Stashed %d files: %s", len(m.StashPaths), message)
+			m.CurrentView = model.FileView
+			for i := range m.Files {
+				m.Files[i].Selected = false
+			}
 		} else {
 			m.Message = fmt.Sprintf("✓ Stash saved: %s", message)
-			m.MessageType = "success"
-			m.CurrentView = model.StashView
-			m.StashMessage = ""
-			m.RefreshData()
 		}
+		m.StashMessage = ""
+		m.StashPaths = nil
+		m.Refresh(model.RefreshFiles | model.RefreshStashes)
 		return m, nil
 
 	case "esc":
 		m.CurrentView = model.StashView
+		if len(m.StashPaths) > 0 {
+			m.CurrentView = model.FileView
+			m.StashPaths = nil
+		}
 		m.StashMessage = ""
 		m.Message = ""
 		m.MessageType = ""
 		return m, nil
 
 	case "backspace":
-		if len(m.StashMessage) > 0 {
+		if m.StashField == model.StashFieldMessage && len(m.StashMessage) > 0 {
 			m.StashMessage = m.StashMessage[:len(m.StashMessage)-1]
 		}
 		return m, nil
 
 	default:
-		if len(msg.Runes) == 1 && msg.Runes[0] >= 32 && msg.Runes[0] <= 126 {
+		if m.StashField == model.StashFieldMessage && len(msg.Runes) == 1 && msg.Runes[0] >= 32 && msg.Runes[0] <= 126 {
 			m.StashMessage += string(msg.Runes)
 		}
 		return m, nil
 	}
 }
+
+// HandleStashInputView processes key messages while naming the branch a
+// stash is turned into, or editing the message of a stash.
+func HandleStashInputView(m model.Model, msg tea.KeyMsg) (model.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		if m.StashInput == "" {
+			return m, nil
+		}
+		if m.StashAction == "branch" {
+			return stashBranch(m), nil
+		}
+		return renameStash(m), nil
+
+	case "esc":
+		m.CurrentView = model.StashView
+		m.Message = ""
+		m.MessageType = ""
+		return m, nil
+
+	case "backspace":
+		if len(m.StashInput) > 0 {
+			m.StashInput = m.StashInput[:len(m.StashInput)-1]
+		}
+		return m, nil
+	}
+
+	if len(msg.Runes) == 1 && utils.IsPrintableChar(msg.Runes[0]) {
+		// branch names can't have spaces
+		if msg.Runes[0] != ' ' || m.StashAction == "rename" {
+			m.StashInput += string(msg.Runes)
+		}
+	}
+	return m, nil
+}
+
+// stashBranch checks out a new branch at the commit the stash was made on
+// and applies the stash there.
+func stashBranch(m model.Model) model.Model {
+	if err := git.StashBranch(m.StashInput, m.StashRef); err != nil {
+		m.Message = fmt.Sprintf("✗ %s", err)
+		m.MessageType = "error"
+		return m
+	}
+	m.CurrentBranch = m.StashInput
+	m.CurrentView = model.FileView
+	m.Cursor = 0
+	m.Message = fmt.Sprintf("✓ Created branch %s from %s", m.StashInput, m.StashRef)
+	m.MessageType = "success"
+	m.Refresh(model.RefreshFiles | model.RefreshBranches | model.RefreshStashes)
+	return m
+}
+
+// renameStash replaces the message of the stash, keeping the "On <branch>"
+// part git put in front of it.
+func renameStash(m model.Model) model.Model {
+	message := m.StashInput
+	if m.Cursor < len(m.Stashes) {
+		if prefix, _ := splitStashMessage(m.Stashes[m.Cursor]); prefix != "" {
+			message = prefix + ": " + message
+		}
+	}
+	if err := git.StashRename(m.StashRef, message); err != nil {
+		m.Message = fmt.Sprintf("✗ Error renaming stash: %s", err)
+		m.MessageType = "error"
+		return m
+	}
+	// the renamed stash is stored again on top
+	m.Cursor = 0
+	m.CurrentView = model.StashView
+	m.Message = fmt.Sprintf("✓ Renamed %s, now stash@{0}", m.StashRef)
+	m.MessageType = "success"
+	m.Refresh(model.RefreshStashes)
+	return m
+}
+
+// splitStashMessage splits a line of git stash list, such as
+// "stash@{1}: On main: fix tests", into the branch part and the message.
+func splitStashMessage(line string) (string, string) {
+	parts := strings.SplitN(line, ": ", 3)
+	switch len(parts) {
+	case 3:
+		return parts[1], parts[2]
+	case 2:
+		return "", parts[1]
+	}
+	return "", ""
+}
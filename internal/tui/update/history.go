@@ -0,0 +1,176 @@
+package update
+
+import (
+	"fmt"
+
+	"froggit/internal/git"
+	"froggit/internal/tui/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// OpenHistoryView lists the commits that changed path, with the commit hash
+// selected when it is among them. esc returns to back.
+func OpenHistoryView(m model.Model, path string, back model.View, hash string) model.Model {
+	commits, err := git.FileHistory(path)
+	if err != nil {
+		m.Message = fmt.Sprintf("✗ %s", err)
+		m.MessageType = "error"
+		return m
+	}
+	if len(commits) == 0 {
+		m.Message = fmt.Sprintf("⚠ %s has no commits yet", path)
+		m.MessageType = "warning"
+		return m
+	}
+
+	m.HistoryPath = path
+	m.HistoryCommits = commits
+	m.HistoryCursor = 0
+	m.HistoryBack = back
+	for i, commit := range commits {
+		if hash != "" && commit.Hash == hash {
+			m.HistoryCursor = i
+			break
+		}
+	}
+	m.CurrentView = model.HistoryView
+	m.Message = ""
+	return m
+}
+
+// openFileHistory opens HistoryView for the file under the cursor.
+func openFileHistory(m model.Model, back model.View) model.Model {
+	if m.Cursor >= len(m.Files) {
+		m.Message = "⚠ No file selected or no files available"
+		m.MessageType = "warning"
+		return m
+	}
+	file := m.Files[m.Cursor]
+	if file.Status == "??" {
+		m.Message = "⚠ Untracked files have no history"
+		m.MessageType = "warning"
+		return m
+	}
+	return OpenHistoryView(m, file.Name, back, "")
+}
+
+// HandleHistoryKey handles key messages in HistoryView.
+func HandleHistoryKey(m model.Model, msg tea.KeyMsg) (model.Model, tea.Cmd) {
+	last := len(m.HistoryCommits) - 1
+	switch msg.String() {
+	case "esc":
+		return closeHistory(m), nil
+	case "up", "k":
+		m.HistoryCursor = max(0, m.HistoryCursor-1)
+	case "down", "j":
+		m.HistoryCursor = max(0, min(last, m.HistoryCursor+1))
+	case "pgup":
+		m.HistoryCursor = max(0, m.HistoryCursor-blamePage)
+	case "pgdown":
+		m.HistoryCursor = max(0, min(last, m.HistoryCursor+blamePage))
+	case "home", "g":
+		m.HistoryCursor = 0
+	case "end", "G":
+		m.HistoryCursor = max(0, last)
+
+	case "enter", "d":
+		return openHistoryDiff(m), nil
+
+	case "c", "r":
+		if m.HistoryCursor > last {
+			return m, nil
+		}
+		commit := m.HistoryCommits[m.HistoryCursor]
+		m.DialogType = "restore_revision"
+		if msg.String() == "c" {
+			m.DialogType = "checkout_revision"
+		}
+		m.DialogTarget = fmt.Sprintf("%s from %s", m.HistoryPath, shortHash(commit.Hash))
+		m.CurrentView = model.ConfirmDialog
+	}
+	return m, nil
+}
+
+// closeHistory goes back to the view HistoryView was opened from.
+func closeHistory(m model.Model) model.Model {
+	m.HistoryCommits = nil
+	m.Message = ""
+	switch {
+	case m.HistoryBack == model.BlameView && len(m.BlameLines) > 0:
+		m.CurrentView = model.BlameView
+	case m.HistoryBack == model.DiffView:
+		m = OpenDiffView(m)
+		if m.CurrentView != model.DiffView {
+			m.CurrentView = model.FileView
+		}
+	default:
+		m.CurrentView = model.FileView
+	}
+	return m
+}
+
+// openHistoryDiff shows the change the selected commit made to the file. A
+// rename shows up as such, with the name the file had before.
+func openHistoryDiff(m model.Model) model.Model {
+	if m.HistoryCursor >= len(m.HistoryCommits) {
+		return m
+	}
+	commit := m.HistoryCommits[m.HistoryCursor]
+	paths := []string{commit.Path}
+	if next := m.HistoryCursor + 1; next < len(m.HistoryCommits) && m.HistoryCommits[next].Path != commit.Path {
+		paths = append(paths, m.HistoryCommits[next].Path)
+	}
+
+	m.DiffRev = commit.Hash
+	m.DiffBase = ""
+	m.DiffPaths = paths
+	m.DiffTitle = fmt.Sprintf("%s in %s %s", commit.Path, shortHash(commit.Hash), commit.Summary)
+	m.DiffBack = model.HistoryView
+	m.DiffViewOffset = 0
+	m, err := loadFileDiff(m)
+	if err != nil {
+		m.DiffRev = ""
+		m.Message = fmt.Sprintf("✗ Error getting diff: %s", err)
+		m.MessageType = "error"
+		return m
+	}
+	m.CurrentView = model.DiffView
+	return m
+}
+
+// RestoreRevision puts the file of HistoryView back as it was in the selected
+// commit once confirmed, staged too when stage is set.
+func RestoreRevision(m model.Model, stage bool) model.Model {
+	m.CurrentView = model.HistoryView
+	if m.HistoryCursor >= len(m.HistoryCommits) {
+		return m
+	}
+	commit := m.HistoryCommits[m.HistoryCursor]
+	if err := git.RestoreFileFrom(commit.Hash, commit.Path, m.HistoryPath, stage); err != nil {
+		m.Message = fmt.Sprintf("✗ %s", err)
+		m.MessageType = "error"
+		return m
+	}
+
+	how := "restored in the worktree"
+	if stage {
+		how = "checked out and staged"
+	}
+	m.Message = fmt.Sprintf("✓ %s %s from %s", m.HistoryPath, how, shortHash(commit.Hash))
+	if commit.Path != m.HistoryPath {
+		m.Message += fmt.Sprintf(" (named %s then)", commit.Path)
+	}
+	m.MessageType = "success"
+	m.Refresh(model.RefreshFiles)
+	return m
+}
+
+// blameHistoryPath returns the file whose history BlameView opens: the
+// blamed file as it is named now, even while an older revision is shown.
+func blameHistoryPath(m model.Model) string {
+	if len(m.BlameStack) > 0 {
+		return m.BlameStack[0].Path
+	}
+	return m.Blame.Path
+}
@@ -0,0 +1,21 @@
+package update
+
+import (
+	"froggit/internal/tui/model"
+	"froggit/internal/tui/update/handlers"
+)
+
+// ignoreFile offers the ways to ignore the untracked file under the cursor.
+// Tracked files stay tracked whatever .gitignore says, so they are refused.
+func ignoreFile(m model.Model) model.Model {
+	if m.Cursor >= len(m.Files) {
+		return m
+	}
+	file := m.Files[m.Cursor]
+	if file.Status != "??" {
+		m.Message = "⚠ Only untracked files can be ignored, [I] explains the rules for a path"
+		m.MessageType = "warning"
+		return m
+	}
+	return handlers.OpenIgnoreView(m, file.Name)
+}
@@ -6,6 +6,8 @@ import (
 
 	"froggit/internal/git"
 	"froggit/internal/tui/model"
+	"froggit/internal/tui/update/async"
+	"froggit/internal/utils"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -14,19 +16,31 @@ import (
 func HandleLogGraphKey(m model.Model, msg tea.KeyMsg) (model.Model, tea.Cmd) {
 	switch msg.String() {
 	case "esc":
+		if m.LogSearchTitle != "" {
+			back := m.LogBack
+			m, cmd := OpenLogGraphView(m)
+			m.LogBack = back
+			return m, cmd
+		}
 		m.CurrentView = model.FileView
+		if m.LogBack == model.BlameView && len(m.BlameLines) > 0 {
+			m.CurrentView = model.BlameView
+		}
+		m.LogBack = model.FileView
+		m.Message = ""
+		return m, nil
+
+	case "s":
+		m.LogSearchInput = ""
+		m.CurrentView = model.LogSearchView
 		return m, nil
 
 	case "up":
-		if m.Cursor > 0 {
-			m.Cursor--
-		}
+		utils.MoveCursor(&m, -1)
 		return m, nil
 
 	case "down":
-		if m.Cursor < len(m.LogLines)-1 {
-			m.Cursor++
-		}
+		utils.MoveCursor(&m, 1)
 		return m, nil
 
 	default:
@@ -44,9 +58,73 @@ func OpenLogGraphView(m model.Model) (model.Model, tea.Cmd) {
 	}
 
 	m.LogLines = strings.Split(strings.TrimSpace(graph), "\n")
+	m.LogSearchTitle = ""
+	m.LogBack = model.FileView
 	m.Cursor = 0
 	m.CurrentView = model.LogGraphView
 	m.Message = ""
 
 	return m, nil
 }
+
+// HandleLogSearchKey handles key messages in LogSearchView, where the kind of
+// commit search and its query are chosen.
+func HandleLogSearchKey(m model.Model, msg tea.KeyMsg) (model.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.CurrentView = model.LogGraphView
+		return m, nil
+
+	case "tab", "right":
+		m.LogSearchKind = git.LogSearchKinds[(int(m.LogSearchKind)+1)%len(git.LogSearchKinds)]
+		return m, nil
+
+	case "shift+tab", "left":
+		n := len(git.LogSearchKinds)
+		m.LogSearchKind = git.LogSearchKinds[(int(m.LogSearchKind)+n-1)%n]
+		return m, nil
+
+	case "enter":
+		if m.LogSearchInput == "" {
+			return m, nil
+		}
+		m.Message = fmt.Sprintf("Searching commits %s...", m.LogSearchKind.Arg(m.LogSearchInput))
+		m.MessageType = "info"
+		return m, async.PerformLogSearch(m.LogSearchKind, m.LogSearchInput)
+
+	case "backspace":
+		if len(m.LogSearchInput) > 0 {
+			m.LogSearchInput = m.LogSearchInput[:len(m.LogSearchInput)-1]
+		}
+		return m, nil
+	}
+
+	if len(msg.Runes) == 1 && utils.IsPrintableChar(msg.Runes[0]) {
+		m.LogSearchInput += string(msg.Runes)
+	}
+	return m, nil
+}
+
+// HandleLogSearchResult shows the commits found by a log search in LogGraphView.
+func HandleLogSearchResult(m model.Model, msg async.LogSearchMsg) model.Model {
+	if msg.Err != nil {
+		m.Message = fmt.Sprintf("✗ Error searching commits: %s", msg.Err)
+		m.MessageType = "error"
+		return m
+	}
+
+	title := msg.Kind.Arg(msg.Query)
+	output := strings.TrimSpace(msg.Output)
+	if output == "" {
+		m.Message = fmt.Sprintf("⚠ No commits match %s", title)
+		m.MessageType = "warning"
+		return m
+	}
+
+	m.LogLines = strings.Split(output, "\n")
+	m.LogSearchTitle = title
+	m.Cursor = 0
+	m.CurrentView = model.LogGraphView
+	m.Message = ""
+	return m
+}
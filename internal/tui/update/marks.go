@@ -0,0 +1,228 @@
+package update
+
+import (
+	"fmt"
+	"strings"
+
+	"froggit/internal/git"
+	"froggit/internal/tui/model"
+	"froggit/internal/tui/update/handlers"
+	"froggit/internal/utils"
+)
+
+// ToggleMark marks or unmarks the file under the cursor for a bulk action and
+// moves on to the next one. On a directory of the tree it marks every file
+// below it, or unmarks them when all of them are marked.
+func ToggleMark(m model.Model) model.Model {
+	if dir, ok := utils.SelectedDir(&m); ok {
+		all := true
+		for _, file := range m.Files {
+			if utils.InDir(file.Name, dir) && !file.Selected {
+				all = false
+				break
+			}
+		}
+		for i := range m.Files {
+			if utils.InDir(m.Files[i].Name, dir) {
+				m.Files[i].Selected = !all
+			}
+		}
+	} else if m.Cursor < len(m.Files) {
+		m.Files[m.Cursor].Selected = !m.Files[m.Cursor].Selected
+	}
+	utils.MoveCursor(&m, 1)
+	return m
+}
+
+// ToggleMarkAll marks every file that passes the filter, or clears the marks
+// when they are all marked already.
+func ToggleMarkAll(m model.Model) model.Model {
+	visible := utils.FileViewOrder(&m)
+	all := true
+	for _, idx := range visible {
+		if !m.Files[idx].Selected {
+			all = false
+			break
+		}
+	}
+	if all {
+		return ClearMarks(m)
+	}
+	for _, idx := range visible {
+		m.Files[idx].Selected = true
+	}
+	return m
+}
+
+// ClearMarks unmarks every file.
+func ClearMarks(m model.Model) model.Model {
+	for i := range m.Files {
+		m.Files[i].Selected = false
+	}
+	return m
+}
+
+// HandleMarkedKey applies the FileView keys for bulk actions to the marked
+// files: staging or unstaging them, discarding, stashing and ignoring them.
+// It reports false when no file is marked or the key has no bulk action.
+func HandleMarkedKey(m model.Model, key string) (model.Model, bool) {
+	marked := m.MarkedFiles()
+	if len(marked) == 0 {
+		return m, false
+	}
+
+	switch key {
+	case " ":
+		m = toggleMarkedStaged(m, marked)
+	case "x":
+		m.DialogType = "discard_marked"
+		m.DialogTarget = fmt.Sprintf("%d marked files", len(marked))
+		m.CurrentView = model.ConfirmDialog
+	case "s":
+		m = handlers.OpenStashMessageView(m, fileNames(marked))
+	case "i":
+		m = ignoreMarked(m, marked)
+	case "d", "o", "t", "B", "H":
+		m.Message = "⚠ Clear the marks with [esc] to work on a single file"
+		m.MessageType = "warning"
+	default:
+		return m, false
+	}
+	return m, true
+}
+
+func fileNames(files []git.FileItem) []string {
+	names := make([]string, len(files))
+	for i, file := range files {
+		names[i] = file.Name
+	}
+	return names
+}
+
+// toggleMarkedStaged stages the marked files, or unstages them when all of
+// them are staged already.
+func toggleMarkedStaged(m model.Model, marked []git.FileItem) model.Model {
+	stage := false
+	for _, file := range marked {
+		if !file.Staged {
+			stage = true
+			break
+		}
+	}
+
+	names := fileNames(marked)
+	m, ok := stagePaths(m, names, stage, fmt.Sprintf("%d files", len(names)))
+	if ok {
+		m = ClearMarks(m)
+	}
+	return m
+}
+
+// DiscardMarked discards the changes of the marked files once confirmed.
+func DiscardMarked(m model.Model) model.Model {
+	var failed []string
+	var lastErr error
+	marked := m.MarkedFiles()
+	for _, file := range marked {
+		if err := git.DiscardChanges(file.Name); err != nil {
+			failed = append(failed, file.Name)
+			lastErr = err
+		}
+	}
+
+	m = ClearMarks(m)
+	if len(failed) > 0 {
+		m.Message = fmt.Sprintf("✗ Error discarding %s: %s", strings.Join(failed, ", "), lastErr)
+		m.MessageType = "error"
+	} else {
+		m.Message = fmt.Sprintf("✓ Changes discarded in %d files", len(marked))
+		m.MessageType = "success"
+	}
+	m.Refresh(model.RefreshFiles)
+	return m
+}
+
+// ignoreMarked adds the marked untracked files to .gitignore. Tracked files
+// stay tracked whatever .gitignore says, so they are left out.
+func ignoreMarked(m model.Model, marked []git.FileItem) model.Model {
+	var untracked []string
+	for _, file := range marked {
+		if file.Status == "??" {
+			untracked = append(untracked, file.Name)
+		}
+	}
+	if len(untracked) == 0 {
+		m.Message = "⚠ Only untracked files can be ignored"
+		m.MessageType = "warning"
+		return m
+	}
+
+	added, err := git.IgnorePaths(untracked)
+	if err != nil {
+		m.Message = fmt.Sprintf("✗ %s", err)
+		m.MessageType = "error"
+		return m
+	}
+
+	m = ClearMarks(m)
+	m.Message = fmt.Sprintf("✓ Added %d paths to .gitignore", added)
+	m.MessageType = "success"
+	if skipped := len(marked) - len(untracked); skipped > 0 {
+		m.Message += fmt.Sprintf(", skipped %d tracked files", skipped)
+		m.MessageType = "warning"
+	}
+	m.Refresh(model.RefreshFiles)
+	return m
+}
+
+// stagePaths stages or unstages paths with a single git command, shows the
+// result on the files right away and reloads them in the background for
+// their new statuses. what names the paths in the message, such as
+// "3 files". It reports false after showing the error when git fails.
+func stagePaths(m model.Model, paths []string, stage bool, what string) (model.Model, bool) {
+	if stage {
+		m.Message = "✓ Staged " + what
+	} else {
+		m.Message = "✓ Unstaged " + what
+	}
+	m.MessageType = "success"
+	// without paths, git would take the pathspec as the whole worktree
+	if len(paths) == 0 {
+		return m, true
+	}
+	var err error
+	if stage {
+		err = git.AddPaths(paths)
+	} else {
+		err = git.ResetPaths(paths)
+	}
+	if err != nil {
+		m.Message = fmt.Sprintf("✗ %s", err)
+		m.MessageType = "error"
+		return m, false
+	}
+
+	changed := make(map[string]bool, len(paths))
+	for _, path := range paths {
+		changed[path] = true
+	}
+	for i := range m.Files {
+		if changed[m.Files[i].Name] {
+			m.Files[i].Staged = stage
+		}
+	}
+	m.Refresh(model.RefreshFiles)
+	return m, true
+}
+
+// stagedNames returns the names of the files that are staged, or of those
+// that aren't.
+func stagedNames(files []git.FileItem, staged bool) []string {
+	var names []string
+	for _, file := range files {
+		if file.Staged == staged {
+			names = append(names, file.Name)
+		}
+	}
+	return names
+}
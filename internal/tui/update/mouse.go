@@ -0,0 +1,150 @@
+package update
+
+import (
+	"froggit/internal/config"
+	"froggit/internal/tui/model"
+	"froggit/internal/tui/zone"
+	"froggit/internal/utils"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// doubleClickTime is the longest gap between two clicks of a double click.
+const doubleClickTime = 400 * time.Millisecond
+
+// HandleMouse maps mouse events onto the zones of the last rendered frame:
+// the wheel scrolls, clicking a row selects it, clicking a footer control
+// presses its key and double-clicking a file stages or unstages it.
+func HandleMouse(m model.Model, cfg config.Config, msg tea.MouseMsg) (model.Model, tea.Cmd) {
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		return scrollWheel(m, cfg, -1)
+	case tea.MouseButtonWheelDown:
+		return scrollWheel(m, cfg, 1)
+	case tea.MouseButtonLeft:
+		if msg.Action != tea.MouseActionPress {
+			return m, nil
+		}
+	default:
+		return m, nil
+	}
+
+	if id, ok := zone.Find(msg.X, msg.Y, "control:"); ok {
+		key, ok := controlKey(strings.TrimPrefix(id, "control:"))
+		if !ok {
+			return m, nil
+		}
+		return Update(m, cfg, key)
+	}
+
+	if id, ok := zone.Find(msg.X, msg.Y, "panelrow:"); ok && m.Dashboard {
+		parts := strings.Split(strings.TrimPrefix(id, "panelrow:"), ":")
+		panel, _ := strconv.Atoi(parts[0])
+		idx, _ := strconv.Atoi(parts[1])
+		if panel != m.Panel {
+			m = FocusPanel(m, panel)
+		}
+		return clickRow(m, cfg, id, idx)
+	}
+
+	if id, ok := zone.Find(msg.X, msg.Y, "panel:"); ok && m.Dashboard {
+		panel, _ := strconv.Atoi(strings.TrimPrefix(id, "panel:"))
+		if panel != m.Panel {
+			m = FocusPanel(m, panel)
+		}
+		return m, nil
+	}
+
+	if id, ok := zone.Find(msg.X, msg.Y, "treedir:"); ok && m.CurrentView == model.FileView {
+		return clickTreeDir(m, cfg, id)
+	}
+
+	if id, ok := zone.Find(msg.X, msg.Y, "row:"); ok {
+		idx, _ := strconv.Atoi(strings.TrimPrefix(id, "row:"))
+		return clickRow(m, cfg, id, idx)
+	}
+
+	return m, nil
+}
+
+// clickRow selects item idx and, on a double click in FileView, toggles
+// whether the file is staged.
+func clickRow(m model.Model, cfg config.Config, id string, idx int) (model.Model, tea.Cmd) {
+	if !utils.SelectItem(&m, idx) {
+		return m, nil
+	}
+
+	now := time.Now()
+	double := id == m.LastClickID && now.Sub(m.LastClickAt) <= doubleClickTime
+	m.LastClickID, m.LastClickAt = id, now
+	if double && m.CurrentView == model.FileView {
+		m.LastClickID = ""
+		return Update(m, cfg, tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}})
+	}
+	return m, nil
+}
+
+// clickTreeDir selects a directory of the file tree and, on a double click,
+// collapses or expands it.
+func clickTreeDir(m model.Model, cfg config.Config, id string) (model.Model, tea.Cmd) {
+	m.TreeDir = strings.TrimPrefix(id, "treedir:")
+	utils.EnsureFileVisible(&m)
+
+	now := time.Now()
+	double := id == m.LastClickID && now.Sub(m.LastClickAt) <= doubleClickTime
+	m.LastClickID, m.LastClickAt = id, now
+	if double {
+		m.LastClickID = ""
+		return Update(m, cfg, tea.KeyMsg{Type: tea.KeyEnter})
+	}
+	return m, nil
+}
+
+// scrollWheel scrolls diffs and the log a few lines per notch and moves
+// through other lists one item at a time.
+func scrollWheel(m model.Model, cfg config.Config, dir int) (model.Model, tea.Cmd) {
+	switch m.CurrentView {
+	case model.DiffView:
+		m.DiffViewOffset = max(0, min(len(m.DiffLines)-1, m.DiffViewOffset+3*dir))
+		return m, nil
+	case model.LogGraphView:
+		utils.MoveCursor(&m, 3*dir)
+		return m, nil
+	}
+
+	if len(utils.ListItems(&m)) > 0 {
+		utils.MoveCursor(&m, dir)
+	}
+	return m, nil
+}
+
+// controlKey turns the key label of a footer control into the key press it
+// stands for. Labels listing several keys, like "n/N", press the first one;
+// arrow labels are navigation hints and are not clickable.
+func controlKey(label string) (tea.KeyMsg, bool) {
+	if label != "/" {
+		label, _, _ = strings.Cut(label, "/")
+	}
+
+	switch label {
+	case "space":
+		return tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}}, true
+	case "enter":
+		return tea.KeyMsg{Type: tea.KeyEnter}, true
+	case "esc":
+		return tea.KeyMsg{Type: tea.KeyEscape}, true
+	case "tab":
+		return tea.KeyMsg{Type: tea.KeyTab}, true
+	case "backspace":
+		return tea.KeyMsg{Type: tea.KeyBackspace}, true
+	}
+
+	runes := []rune(label)
+	if len(runes) != 1 || strings.ContainsAny(label, "↑↓←→") {
+		return tea.KeyMsg{}, false
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: runes}, true
+}
@@ -0,0 +1,107 @@
+package update
+
+import (
+	"fmt"
+
+	"froggit/internal/tui/model"
+	"froggit/internal/utils"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// HandleSearchKey handles incremental search with n/N in DiffView, started
+// with "/", and in LogGraphView, started with "f" since "/" filters the log.
+// It returns false when the key should be processed by the view.
+func HandleSearchKey(m model.Model, msg tea.KeyMsg) (model.Model, bool) {
+	// a search only lives as long as the view it was typed in
+	if m.SearchView != m.CurrentView {
+		m = clearSearch(m)
+	}
+	if !utils.IsSearchable(m.CurrentView) {
+		return m, false
+	}
+
+	if m.SearchTyping {
+		switch msg.String() {
+		case "esc":
+			m = restoreSearchOrigin(m)
+			m = clearSearch(m)
+			return m, true
+		case "enter":
+			m.SearchTyping = false
+			if m.Search == "" {
+				m = clearSearch(m)
+			} else if m.SearchMatch < 0 {
+				m.Message = fmt.Sprintf("⚠ Pattern not found: %s", m.Search)
+				m.MessageType = "warning"
+			}
+			return m, true
+		case "backspace":
+			if len(m.Search) > 0 {
+				runes := []rune(m.Search)
+				m.Search = string(runes[:len(runes)-1])
+			}
+		default:
+			if len(msg.Runes) == 1 && utils.IsPrintableChar(msg.Runes[0]) {
+				m.Search += string(msg.Runes)
+			} else {
+				return m, true
+			}
+		}
+
+		// incremental: every keystroke searches again from where it started
+		m = restoreSearchOrigin(m)
+		if m.Search != "" {
+			utils.JumpToMatch(&m, m.SearchOrigin, 1)
+		} else {
+			m.SearchMatch = -1
+		}
+		return m, true
+	}
+
+	switch msg.String() {
+	case utils.SearchKey(m.CurrentView):
+		m.Search = ""
+		m.SearchView = m.CurrentView
+		m.SearchTyping = true
+		m.SearchOrigin = utils.SearchFrom(&m)
+		m.SearchMatch = -1
+		return m, true
+	case "n", "N":
+		if !m.SearchActive() {
+			return m, false
+		}
+		dir := 1
+		if msg.String() == "N" {
+			dir = -1
+		}
+		if !utils.JumpToMatch(&m, utils.SearchFrom(&m)+dir, dir) {
+			m.Message = fmt.Sprintf("⚠ Pattern not found: %s", m.Search)
+			m.MessageType = "warning"
+		}
+		return m, true
+	case "esc":
+		if m.SearchActive() {
+			m = clearSearch(m)
+			return m, true
+		}
+	}
+	return m, false
+}
+
+// restoreSearchOrigin moves the view back to where the search started.
+func restoreSearchOrigin(m model.Model) model.Model {
+	if m.CurrentView == model.DiffView {
+		m.DiffViewOffset = m.SearchOrigin
+	} else {
+		m.Cursor = m.SearchOrigin
+	}
+	return m
+}
+
+func clearSearch(m model.Model) model.Model {
+	m.Search = ""
+	m.SearchTyping = false
+	m.SearchMatch = -1
+	return m
+}
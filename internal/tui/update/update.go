@@ -28,6 +28,12 @@ func Update(m model.Model, cfg config.Config, msg tea.Msg) (model.Model, tea.Cmd
 		cmds = append(cmds, async.PerformRemoteChangesCheck(m.CurrentBranch))
 	}
 
+	if m.CurrentView == model.AskpassView {
+		if key, ok := msg.(tea.KeyMsg); ok {
+			return handlers.HandleAskpassView(m, key)
+		}
+	}
+
 	if m.CurrentView == model.ConfirmCloneRepoView {
 		if key, ok := msg.(tea.KeyMsg); ok {
 			var handled bool
@@ -40,6 +46,15 @@ func Update(m model.Model, cfg config.Config, msg tea.Msg) (model.Model, tea.Cmd
 
 	switch msg := msg.(type) {
 
+	case tea.WindowSizeMsg:
+		// views size themselves to the terminal in tui.Layout
+		m.Width = msg.Width
+		m.Height = msg.Height
+		return m, tea.Batch(cmds...)
+
+	case tea.MouseMsg:
+		return HandleMouse(m, cfg, msg)
+
 	case messages.SwitchBranchMsg:
 		if msg.Err != nil {
 			m.Message = fmt.Sprintf("✗ Error switching to branch %s: %s", msg.TargetBranch, msg.Err)
@@ -48,7 +63,7 @@ func Update(m model.Model, cfg config.Config, msg tea.Msg) (model.Model, tea.Cmd
 		}
 
 		m.CurrentBranch = msg.TargetBranch
-		m.RefreshData()
+		m.Refresh(model.RefreshHead)
 		if msg.NextAction == "merge" {
 			err := git.Merge(msg.SourceBranch)
 
@@ -56,6 +71,7 @@ func Update(m model.Model, cfg config.Config, msg tea.Msg) (model.Model, tea.Cmd
 
 			if len(conflicts) > 0 {
 				m.LogLines = conflicts
+				m.ConflictCursor = 0
 				m.Message = fmt.Sprintf("Conflicts detected while merging %s into %s. Please resolve them and use [P] Proceed or [X] Cancel.", msg.SourceBranch, msg.TargetBranch)
 				m.MessageType = "warning"
 				return m, nil
@@ -77,6 +93,7 @@ func Update(m model.Model, cfg config.Config, msg tea.Msg) (model.Model, tea.Cmd
 
 			if len(conflicts) > 0 {
 				m.LogLines = conflicts
+				m.ConflictCursor = 0
 				m.Message = fmt.Sprintf("Conflicts detected while rebasing %s onto %s. Please resolve them and use [P] Proceed or [X] Cancel.", msg.SourceBranch, msg.TargetBranch)
 				m.MessageType = "warning"
 				return m, nil
@@ -90,13 +107,25 @@ func Update(m model.Model, cfg config.Config, msg tea.Msg) (model.Model, tea.Cmd
 				m.CurrentView = model.FileView
 				m.DialogTarget = ""
 				m.LogLines = nil
-				m.RefreshData()
+				m.Refresh(model.RefreshHead)
 			}
 			return m, nil
 		}
 
 	case tea.KeyMsg:
 
+		if filtered, handled := HandleFilterKey(m, msg); handled {
+			return filtered, tea.Batch(cmds...)
+		}
+
+		if searched, handled := HandleSearchKey(m, msg); handled {
+			return searched, tea.Batch(cmds...)
+		}
+
+		if focused, handled := HandleDashboardKey(m, msg); handled {
+			return focused, tea.Batch(cmds...)
+		}
+
 		if m.CurrentView == model.QuickStartView {
 			switch msg.String() {
 			case "up":
@@ -138,14 +167,10 @@ func Update(m model.Model, cfg config.Config, msg tea.Msg) (model.Model, tea.Cmd
 		if m.CurrentView == model.RepositoryListView {
 			switch msg.String() {
 			case "up":
-				if m.SelectedRepoIndex > 0 {
-					m.SelectedRepoIndex--
-				}
+				utils.MoveCursor(&m, -1)
 				return m, nil
 			case "down":
-				if m.SelectedRepoIndex < len(m.Repositories)-1 {
-					m.SelectedRepoIndex++
-				}
+				utils.MoveCursor(&m, 1)
 				return m, nil
 			case "esc":
 				m.CurrentView = model.GitHubControlsView
@@ -163,25 +188,39 @@ func Update(m model.Model, cfg config.Config, msg tea.Msg) (model.Model, tea.Cmd
 			return HandleLogGraphKey(m, msg)
 		}
 
+		if m.CurrentView == model.LogSearchView {
+			return HandleLogSearchKey(m, msg)
+		}
+
+		if m.CurrentView == model.BlameView {
+			if msg.String() == "o" {
+				return OpenEditor(m, cfg.Ui.Editor)
+			}
+			return HandleBlameKey(m, msg)
+		}
+
+		if m.CurrentView == model.HistoryView {
+			return HandleHistoryKey(m, msg)
+		}
+
+		if m.CurrentView == model.CompareView {
+			return HandleCompareKey(m, msg)
+		}
+
+		if m.CurrentView == model.FilePickView {
+			return HandleFilePickKey(m, msg)
+		}
+
 		if m.CurrentView == model.DiffView {
-			switch msg.String() {
-			case "esc":
-				m.CurrentView = model.FileView
-				m.DiffLines = nil
-				m.DiffViewOffset = 0
-				return m, nil
-			case "up", "k":
-				if m.DiffViewOffset > 0 {
-					m.DiffViewOffset--
-				}
-				return m, nil
-			case "down", "j":
-				if m.DiffViewOffset < len(m.DiffLines)-1 {
-					m.DiffViewOffset++
-				}
-				return m, nil
-			default:
-				return m, nil
+			if msg.String() == "o" && m.DiffRev == "" {
+				return OpenEditor(m, cfg.Ui.Editor)
+			}
+			return HandleDiffKey(m, msg)
+		}
+
+		if m.CurrentView == model.MergeView || m.CurrentView == model.RebaseView {
+			if msg.String() == "o" && len(m.LogLines) > 0 {
+				return OpenEditor(m, cfg.Ui.Editor)
 			}
 		}
 
@@ -201,6 +240,42 @@ func Update(m model.Model, cfg config.Config, msg tea.Msg) (model.Model, tea.Cmd
 			return handlers.HandleStashMessageView(m, msg)
 		}
 
+		if m.CurrentView == model.StashInputView {
+			return handlers.HandleStashInputView(m, msg)
+		}
+
+		if m.CurrentView == model.IgnoreView {
+			return handlers.HandleIgnoreView(m, msg)
+		}
+
+		if m.CurrentView == model.IgnoreCheckView {
+			return handlers.HandleIgnoreCheckView(m, msg)
+		}
+
+		if m.CurrentView == model.PushView {
+			return handlers.HandlePushView(m, msg)
+		}
+
+		if m.CurrentView == model.PullView {
+			return handlers.HandlePullView(m, msg)
+		}
+
+		if m.CurrentView == model.SetUpstreamView {
+			return handlers.HandleSetUpstreamView(m, msg)
+		}
+
+		if m.CurrentView == model.RenameBranchView {
+			return handlers.HandleRenameBranchView(m, msg)
+		}
+
+		if m.CurrentView == model.CleanupView {
+			return handlers.HandleCleanupView(m, msg)
+		}
+
+		if m.CurrentView == model.ConfirmDialog && m.DialogType == "force_delete_branch" {
+			return handlers.HandleForceDeleteBranch(m, msg)
+		}
+
 		if m.CurrentView == model.ConfirmDialog {
 			switch msg.String() {
 			case "y":
@@ -212,7 +287,7 @@ func Update(m model.Model, cfg config.Config, msg tea.Msg) (model.Model, tea.Cmd
 					} else {
 						m.Message = "✓ Branch deleted successfully"
 						m.MessageType = "success"
-						m.RefreshData()
+						m.Refresh(model.RefreshBranches)
 					}
 				case "delete_remote":
 					if err := git.RemoveRemote(m.DialogTarget); err != nil {
@@ -221,8 +296,12 @@ func Update(m model.Model, cfg config.Config, msg tea.Msg) (model.Model, tea.Cmd
 					} else {
 						m.Message = "✓ Remote deleted successfully"
 						m.MessageType = "success"
-						m.RefreshData()
+						m.Refresh(model.RefreshBranches)
 					}
+				case "discard_dir":
+					m = DiscardDir(m)
+				case "discard_marked":
+					m = DiscardMarked(m)
 				case "discard_changes":
 					if err := git.DiscardChanges(m.DialogTarget); err != nil {
 						m.Message = fmt.Sprintf("✗ Error discarding changes: %s", err)
@@ -230,8 +309,16 @@ func Update(m model.Model, cfg config.Config, msg tea.Msg) (model.Model, tea.Cmd
 					} else {
 						m.Message = "✓ Changes discarded"
 						m.MessageType = "success"
-						m.RefreshData()
+						m.Refresh(model.RefreshFiles)
 					}
+				case "cleanup_branches":
+					m = handlers.DeleteCleanupSelection(m)
+					return m, nil
+				case "delete_remote_branch":
+					m.CurrentView = model.BranchView
+					m.Message = fmt.Sprintf("Deleting %s...", m.DialogTarget)
+					m.MessageType = "info"
+					return m, async.PerformDeleteRemoteBranch(m.DeletingRemote)
 				case "drop_stash":
 					if err := git.StashDrop(m.DialogTarget); err != nil {
 						m.Message = fmt.Sprintf("✗ Error dropping stash: %s", err)
@@ -239,15 +326,25 @@ func Update(m model.Model, cfg config.Config, msg tea.Msg) (model.Model, tea.Cmd
 					} else {
 						m.Message = "✓ Stash dropped successfully"
 						m.MessageType = "success"
-						m.RefreshData()
+						m.Refresh(model.RefreshStashes)
 					}
 					m.CurrentView = model.StashView
 					return m, nil
+				case "restore_revision", "checkout_revision":
+					return RestoreRevision(m, m.DialogType == "checkout_revision"), nil
 				}
 				m.CurrentView = model.FileView
 				return m, nil
 
 			case "n", "esc":
+				if m.DialogType == "cleanup_branches" {
+					m.CurrentView = model.CleanupView
+					return m, nil
+				}
+				if m.DialogType == "restore_revision" || m.DialogType == "checkout_revision" {
+					m.CurrentView = model.HistoryView
+					return m, nil
+				}
 				m.CurrentView = model.FileView
 				return m, nil
 			}
@@ -272,7 +369,7 @@ func Update(m model.Model, cfg config.Config, msg tea.Msg) (model.Model, tea.Cmd
 				m.Message = fmt.Sprintf("Current branch: %s - Select target branch to merge INTO", m.CurrentBranch)
 				m.MessageType = "info"
 				if len(m.Branches) == 0 {
-					m.RefreshData()
+					m.Refresh(model.RefreshBranches)
 				}
 				return m, nil
 			}
@@ -285,29 +382,34 @@ func Update(m model.Model, cfg config.Config, msg tea.Msg) (model.Model, tea.Cmd
 				m.MessageType = "info"
 				m.LogLines = nil
 				if len(m.Branches) == 0 {
-					m.RefreshData()
+					m.Refresh(model.RefreshBranches)
 				}
 				return m, nil
 			}
+		case "C":
+			if m.CurrentView == model.FileView && m.AdvancedMode {
+				return OpenCompareView(m, git.ResolveDefaultBranch(cfg.Git.DefaultBranch), m.CurrentBranch), nil
+			}
+		case "B":
+			if m.CurrentView == model.FileView && m.AdvancedMode {
+				return OpenFilePickView(m, model.BlameView), nil
+			}
+		case "H":
+			if m.CurrentView == model.FileView && m.AdvancedMode {
+				return OpenFilePickView(m, model.HistoryView), nil
+			}
 		case "S":
 			if m.CurrentView == model.FileView && m.AdvancedMode {
 				m.CurrentView = model.StashView
 				m.Cursor = 0
 				m.Message = ""
 				m.MessageType = ""
-				m.RefreshData()
+				m.Refresh(model.RefreshStashes)
 				return m, nil
 			}
 		case "a":
 			if m.CurrentView == model.FileView && !m.AdvancedMode {
-				for i := range m.Files {
-					if !m.Files[i].Staged {
-						m.Files[i].Staged = true
-						git.Add(m.Files[i].Name)
-					}
-				}
-				m.Message = "✓ All files added to stage"
-				m.MessageType = "success"
+				m, _ = stagePaths(m, stagedNames(m.Files, false), true, "all files")
 				return m, nil
 			}
 
@@ -324,6 +426,9 @@ func Update(m model.Model, cfg config.Config, msg tea.Msg) (model.Model, tea.Cmd
 			}
 
 		case "esc":
+			if m.CurrentView == model.FileView && len(m.MarkedFiles()) > 0 {
+				return ClearMarks(m), nil
+			}
 			if m.AdvancedMode {
 				m.AdvancedMode = false
 				if m.CurrentView == model.LogGraphView {
@@ -359,7 +464,7 @@ func Update(m model.Model, cfg config.Config, msg tea.Msg) (model.Model, tea.Cmd
 						m.MessageType = "success"
 						m.CurrentView = model.FileView
 						m.CommitMsg = ""
-						m.RefreshData()
+						m.Refresh(model.RefreshFiles | model.RefreshBranches | model.RefreshCommits)
 					}
 				}
 				return m, nil
@@ -374,7 +479,7 @@ func Update(m model.Model, cfg config.Config, msg tea.Msg) (model.Model, tea.Cmd
 						m.MessageType = "success"
 						m.CurrentView = model.BranchView
 						m.NewBranchName = ""
-						m.RefreshData()
+						m.Refresh(model.RefreshBranches)
 					}
 				}
 				return m, nil
@@ -393,7 +498,7 @@ func Update(m model.Model, cfg config.Config, msg tea.Msg) (model.Model, tea.Cmd
 						m.RemoteName = ""
 						m.RemoteURL = ""
 						m.InputField = ""
-						m.RefreshData()
+						m.Refresh(model.RefreshBranches)
 					}
 				} else if m.InputField == "" {
 					m.InputField = "name"
@@ -401,6 +506,18 @@ func Update(m model.Model, cfg config.Config, msg tea.Msg) (model.Model, tea.Cmd
 				return m, nil
 
 			case model.BranchView:
+				if remote, ok := m.SelectedRemoteBranch(); ok {
+					if err := git.CheckoutTracking(remote); err != nil {
+						m.Message = fmt.Sprintf("✗ Error checking out %s: %s", remote.Ref(), err)
+						m.MessageType = "error"
+					} else {
+						m.Message = fmt.Sprintf("✓ Switched to new branch %s tracking %s", remote.Name, remote.Ref())
+						m.MessageType = "success"
+						m.CurrentBranch = remote.Name
+						m.Refresh(model.RefreshHead)
+					}
+					return m, nil
+				}
 				if len(m.Branches) > 0 {
 					if m.Cursor >= len(m.Branches) {
 						m.Cursor = len(m.Branches) - 1
@@ -414,7 +531,7 @@ func Update(m model.Model, cfg config.Config, msg tea.Msg) (model.Model, tea.Cmd
 							m.Message = fmt.Sprintf("✓ Switched to branch %s", selected)
 							m.MessageType = "success"
 							m.CurrentBranch = selected
-							m.RefreshData()
+							m.Refresh(model.RefreshHead)
 						}
 					} else {
 						m.Message = "⚠ You are already on this branch"
@@ -444,28 +561,18 @@ func Update(m model.Model, cfg config.Config, msg tea.Msg) (model.Model, tea.Cmd
 			return m, nil
 
 		case "up":
-			if m.CurrentView != model.CommitView && m.CurrentView != model.NewBranchView && m.CurrentView != model.AddRemoteView && m.Cursor > 0 {
+			if m.CurrentView == model.FileView || m.CurrentView == model.BranchView {
+				utils.MoveCursor(&m, -1)
+			} else if m.CurrentView != model.CommitView && m.CurrentView != model.NewBranchView && m.CurrentView != model.AddRemoteView && m.Cursor > 0 {
 				m.Cursor--
-				if m.CurrentView == model.FileView && m.Cursor < m.FileViewOffset {
-					m.FileViewOffset = m.Cursor
-				}
 			}
 			return m, nil
 
 		case "down":
 			if m.CurrentView != model.CommitView && m.CurrentView != model.NewBranchView && m.CurrentView != model.AddRemoteView {
 				switch m.CurrentView {
-				case model.FileView:
-					if m.Cursor < len(m.Files)-1 {
-						m.Cursor++
-						if m.Cursor >= m.FileViewOffset+m.FileViewHeight {
-							m.FileViewOffset = m.Cursor - m.FileViewHeight + 1
-						}
-					}
-				case model.BranchView:
-					if m.Cursor < len(m.Branches)-1 {
-						m.Cursor++
-					}
+				case model.FileView, model.BranchView:
+					utils.MoveCursor(&m, 1)
 				case model.RemoteView:
 					if m.Cursor < len(m.Remotes)-1 {
 						m.Cursor++
@@ -518,16 +625,50 @@ func Update(m model.Model, cfg config.Config, msg tea.Msg) (model.Model, tea.Cmd
 				m.NewBranchName = ""
 				return m, nil
 			case "d":
-				if len(m.Branches) > 0 && m.Cursor < len(m.Branches) {
-					toDel := m.Branches[m.Cursor]
-					if toDel == m.CurrentBranch {
-						m.Message = "✗ Cannot delete current branch"
-						m.MessageType = "error"
-					} else {
-						m.DialogType = "delete_branch"
-						m.DialogTarget = toDel
-						m.CurrentView = model.ConfirmDialog
-					}
+				if remote, ok := m.SelectedRemoteBranch(); ok {
+					m.DialogType = "delete_remote_branch"
+					m.DeletingRemote = remote
+					m.DialogTarget = remote.Ref()
+					m.CurrentView = model.ConfirmDialog
+					return m, nil
+				}
+				m = handlers.RequestDeleteBranch(m, false)
+				return m, nil
+			case "D":
+				m = handlers.RequestDeleteBranch(m, true)
+				return m, nil
+			case "r":
+				m = handlers.OpenRenameBranchView(m)
+				return m, nil
+			case "C":
+				m = handlers.OpenCleanupView(m, git.ResolveDefaultBranch(cfg.Git.DefaultBranch))
+				return m, nil
+			case "c":
+				if remote, ok := m.SelectedRemoteBranch(); ok {
+					return OpenCompareView(m, m.CurrentBranch, remote.Ref()), nil
+				}
+				if m.Cursor < len(m.Branches) {
+					return OpenCompareView(m, m.CurrentBranch, m.Branches[m.Cursor]), nil
+				}
+				return m, nil
+			case "u":
+				m = handlers.OpenSetUpstreamView(m)
+				return m, nil
+			case "U":
+				m = handlers.UnsetUpstream(m)
+				return m, nil
+			case "F":
+				remote := handlers.SelectedBranchRemote(m)
+				if remote == "" {
+					m.Message = "⚠ No remote to fetch"
+					m.MessageType = "warning"
+					return m, nil
+				}
+				if !m.IsFetching {
+					m.IsFetching = true
+					m.Message = fmt.Sprintf("Fetching %s...", remote)
+					m.MessageType = "info"
+					return m, tea.Batch(async.PerformFetchRemote(remote), async.Spinner())
 				}
 				return m, nil
 			}
@@ -554,76 +695,57 @@ func Update(m model.Model, cfg config.Config, msg tea.Msg) (model.Model, tea.Cmd
 		}
 
 		if m.CurrentView == model.FileView {
+			if m, ok := HandleMarkedKey(m, msg.String()); ok {
+				return m, nil
+			}
+			if m, ok := HandleTreeDirKey(m, msg.String()); ok {
+				return m, nil
+			}
 			switch msg.String() {
+			case "T":
+				return ToggleFileTree(m), nil
+			case "v":
+				return ToggleMark(m), nil
+			case "V":
+				return ToggleMarkAll(m), nil
+			case "B":
+				return OpenBlameView(m), nil
+			case "H":
+				return openFileHistory(m, model.FileView), nil
+			case "i":
+				return ignoreFile(m), nil
+			case "I":
+				path := ""
+				if m.Cursor < len(m.Files) {
+					path = m.Files[m.Cursor].Name
+				}
+				return handlers.OpenIgnoreCheckView(m, path), nil
 			case " ":
-				if len(m.Files) > 0 && m.Cursor < len(m.Files) {
-					f := &m.Files[m.Cursor]
-					currentFileName := f.Name
-					f.Staged = !f.Staged
-					if f.Staged {
-						git.Add(f.Name)
-						m.Message = fmt.Sprintf("✓ File %s added to stage", f.Name)
-					} else {
-						git.Reset(f.Name)
-						m.Message = fmt.Sprintf("✓ File %s removed from stage", f.Name)
-					}
-					m.MessageType = "success"
-					// Refresh files to update status (?, A, M, etc.)
-					files, _ := git.GetModifiedFiles()
-					m.Files = files
-					// Restore cursor to the same file
-					for i, file := range m.Files {
-						if file.Name == currentFileName {
-							m.Cursor = i
-							break
-						}
-					}
-					// Validate cursor is within bounds
-					if m.Cursor >= len(m.Files) && len(m.Files) > 0 {
-						m.Cursor = len(m.Files) - 1
-					}
+				if m.Cursor < len(m.Files) {
+					f := m.Files[m.Cursor]
+					m, _ = stagePaths(m, []string{f.Name}, !f.Staged, f.Name)
+				} else if len(m.Files) > 0 {
+					m.Cursor = 0
 				} else {
-					if len(m.Files) > 0 {
-						m.Cursor = 0
-					} else {
-						m.Message = "⚠ No files to stage"
-						m.MessageType = "warning"
-					}
+					m.Message = "⚠ No files to stage"
+					m.MessageType = "warning"
 				}
 			case "a":
 				if len(m.Files) > 0 {
-					currentFileName := ""
-					if m.Cursor < len(m.Files) {
-						currentFileName = m.Files[m.Cursor].Name
-					}
-					for i := range m.Files {
-						if !m.Files[i].Staged {
-							m.Files[i].Staged = true
-							git.Add(m.Files[i].Name)
-						}
-					}
-					m.Message = "✓ All files added to stage"
-					m.MessageType = "success"
-					// Refresh files to update status (?, A, M, etc.)
-					files, _ := git.GetModifiedFiles()
-					m.Files = files
-					// Restore cursor to the same file
-					if currentFileName != "" {
-						for i, file := range m.Files {
-							if file.Name == currentFileName {
-								m.Cursor = i
-								break
-							}
-						}
-					}
-					// Validate cursor is within bounds
-					if m.Cursor >= len(m.Files) && len(m.Files) > 0 {
-						m.Cursor = len(m.Files) - 1
-					}
+					m, _ = stagePaths(m, stagedNames(m.Files, false), true, "all files")
 				} else {
 					m.Message = "⚠ No files to stage"
 					m.MessageType = "warning"
 				}
+			case "U":
+				m.StatusOptions.SkipUntracked = !m.StatusOptions.SkipUntracked
+				if m.StatusOptions.SkipUntracked {
+					m.Message = "✓ Untracked files hidden"
+				} else {
+					m.Message = "Listing untracked files..."
+				}
+				m.MessageType = "info"
+				m.Refresh(model.RefreshFiles)
 			case "u":
 				hasStaged := false
 				for _, f := range m.Files {
@@ -633,31 +755,7 @@ func Update(m model.Model, cfg config.Config, msg tea.Msg) (model.Model, tea.Cmd
 					}
 				}
 				if hasStaged {
-					currentFileName := ""
-					if m.Cursor < len(m.Files) {
-						currentFileName = m.Files[m.Cursor].Name
-					}
-					for i := range m.Files {
-						if m.Files[i].Staged {
-							m.Files[i].Staged = false
-							git.Reset(m.Files[i].Name)
-						}
-					}
-					m.Message = "✓ All files unstaged"
-					m.MessageType = "success"
-					files, _ := git.GetModifiedFiles()
-					m.Files = files
-					if currentFileName != "" {
-						for i, file := range m.Files {
-							if file.Name == currentFileName {
-								m.Cursor = i
-								break
-							}
-						}
-					}
-					if m.Cursor >= len(m.Files) && len(m.Files) > 0 {
-						m.Cursor = len(m.Files) - 1
-					}
+					m, _ = stagePaths(m, stagedNames(m.Files, true), false, "all files")
 				} else {
 					m.Message = "⚠ No staged files to unstage"
 					m.MessageType = "warning"
@@ -686,11 +784,14 @@ func Update(m model.Model, cfg config.Config, msg tea.Msg) (model.Model, tea.Cmd
 				m.Cursor = 0
 				m.Message = ""
 			case "r":
-				m.RefreshData()
-				utils.ValidateCursor(&m)
+				m.Refresh(model.RefreshHead)
 				m.Message = "✓ Status updated"
 				m.MessageType = "success"
 			case "p":
+				if m.AdvancedMode {
+					m = handlers.OpenPushView(m)
+					return m, nil
+				}
 				hasCommits, err := git.HasCommitsToush()
 				if err != nil {
 					m.Message = fmt.Sprintf("✗ Error checking commits: %s", err)
@@ -718,11 +819,16 @@ func Update(m model.Model, cfg config.Config, msg tea.Msg) (model.Model, tea.Cmd
 					return m, tea.Batch(async.PerformFetch(), async.Spinner())
 				}
 			case "l":
+				if m.AdvancedMode {
+					ahead, behind, _ := git.AheadBehind("HEAD", "@{u}")
+					m = handlers.OpenPullView(m, ahead, behind, cfg.Git.PullStrategy)
+					return m, nil
+				}
 				if !m.IsPulling {
 					m.IsPulling = true
 					m.Message = "Pulling..."
 					m.MessageType = "info"
-					return m, tea.Batch(async.PerformPull(), async.Spinner())
+					return m, tea.Batch(async.PerformPull(cfg.Git.PullStrategy), async.Spinner())
 				}
 			case "L":
 				m, cmd := OpenLogGraphView(m)
@@ -731,6 +837,10 @@ func Update(m model.Model, cfg config.Config, msg tea.Msg) (model.Model, tea.Cmd
 			case "A":
 				m.MessageType = "info"
 				return m, nil
+			case "t":
+				return OpenExternalTool(m)
+			case "o":
+				return OpenEditor(m, cfg.Ui.Editor)
 			case "x":
 				if len(m.Files) > 0 && m.Cursor < len(m.Files) {
 					m.DialogType = "discard_changes"
@@ -742,17 +852,7 @@ func Update(m model.Model, cfg config.Config, msg tea.Msg) (model.Model, tea.Cmd
 				}
 			case "d":
 				if len(m.Files) > 0 && m.Cursor < len(m.Files) {
-					file := m.Files[m.Cursor]
-					diff, err := git.GetFileDiff(file.Name, file.Staged)
-					if err != nil {
-						m.Message = fmt.Sprintf("✗ Error getting diff: %s", err)
-						m.MessageType = "error"
-					} else {
-						m.DiffLines = strings.Split(diff, "\n")
-						m.DiffViewOffset = 0
-						m.CurrentView = model.DiffView
-					}
-					return m, nil
+					return OpenDiffView(m), nil
 				}
 			case "?":
 				if m.CurrentView == model.FileView {
@@ -772,6 +872,34 @@ func Update(m model.Model, cfg config.Config, msg tea.Msg) (model.Model, tea.Cmd
 		}
 		return m, nil
 
+	case async.AskpassPromptMsg:
+		return handlers.OpenAskpassPrompt(m, msg)
+
+	case async.RepoChangedMsg:
+		if msg.Err != nil {
+			m.Message = fmt.Sprintf("⚠ %s", msg.Err)
+			m.MessageType = "warning"
+		}
+		m.Refresh(msg.Scope)
+		return m, async.WaitForRepoChange(m.Watcher)
+
+	case async.RefreshMsg:
+		shown := shownDiffFile(m)
+		selected := selectedFile(m)
+		m.FinishRefresh(msg.Data)
+		if selected != "" && msg.Data.Scope&model.RefreshFiles != 0 {
+			m = keepFileCursor(m, selected)
+		}
+		// the cursor is checked against the data it moves over, now loaded
+		utils.ValidateCursor(&m)
+		if shown != "" && msg.Data.Scope&model.RefreshFiles != 0 {
+			m = followDiffFile(m, shown)
+		}
+		return m, nil
+
+	case async.DetailMsg:
+		return ApplyDetail(m, msg), nil
+
 	case async.AICommitMsg:
 		m.IsGeneratingAI = false
 		if msg.Err != nil {
@@ -789,14 +917,16 @@ func Update(m model.Model, cfg config.Config, msg tea.Msg) (model.Model, tea.Cmd
 		if msg.Err != nil {
 			m.Message = fmt.Sprintf("✗ Error pushing changes: %s", msg.Err)
 			m.MessageType = "error"
+		} else if msg.DryRun {
+			m.Message = "✓ Dry run: " + strings.ReplaceAll(msg.Output, "\n", " | ")
+			m.MessageType = "success"
 		} else {
 			m.Message = "✓ Changes pushed to remote successfully"
 			m.MessageType = "success"
 			m.CurrentView = model.FileView
 			m.DialogTarget = ""
 			m.LogLines = nil
-			m.RefreshData()
-			utils.ValidateCursor(&m)
+			m.Refresh(model.RefreshBranches | model.RefreshCommits)
 		}
 
 	case async.FetchMsg:
@@ -807,22 +937,38 @@ func Update(m model.Model, cfg config.Config, msg tea.Msg) (model.Model, tea.Cmd
 		} else {
 			m.Message = "✓ Changes fetched successfully"
 			m.MessageType = "success"
-			m.RefreshData()
-			utils.ValidateCursor(&m)
+			m.Refresh(model.RefreshBranches | model.RefreshCommits)
 		}
 
 	case async.PullMsg:
-		m.IsPulling = false
+		m = handlers.HandlePullResult(m, msg)
+
+	case async.RemoteBranchDeleteMsg:
 		if msg.Err != nil {
-			m.Message = fmt.Sprintf("✗ Error pulling changes: %s", msg.Err)
+			m.Message = fmt.Sprintf("✗ Error deleting %s: %s", msg.Branch.Ref(), msg.Err)
 			m.MessageType = "error"
 		} else {
-			m.Message = "✓ Changes pulled successfully"
+			m.Message = fmt.Sprintf("✓ Deleted %s on %s", msg.Branch.Name, msg.Branch.Remote)
 			m.MessageType = "success"
-			m.RefreshData()
-			utils.ValidateCursor(&m)
+			m.Refresh(model.RefreshBranches)
 		}
 
+	case async.RemoteBranchRenameMsg:
+		if msg.Err != nil {
+			m.Message = fmt.Sprintf("✗ Error renaming on %s: %s", msg.Remote, msg.Err)
+			m.MessageType = "error"
+		} else {
+			m.Message = fmt.Sprintf("✓ Renamed to %s on %s", msg.NewName, msg.Remote)
+			m.MessageType = "success"
+			m.Refresh(model.RefreshBranches)
+		}
+
+	case async.ExternalToolMsg:
+		return HandleExternalToolResult(m, msg), nil
+
+	case async.LogSearchMsg:
+		m = HandleLogSearchResult(m, msg)
+
 	case async.RemoteChangesCheckMsg:
 		if msg.Err == nil {
 			m.HasRemoteChanges = msg.HasChanges
@@ -847,3 +993,35 @@ func Update(m model.Model, cfg config.Config, msg tea.Msg) (model.Model, tea.Cmd
 	}
 	return m, nil
 }
+
+// StartRefresh starts loading the repository state requested during the last
+// update in the background, unless a load is already running; its result
+// comes back as a RefreshMsg.
+func StartRefresh(m model.Model) (model.Model, tea.Cmd) {
+	scope, ok := m.StartRefresh()
+	if !ok {
+		return m, nil
+	}
+	return m, async.PerformRefresh(scope, m.Dashboard, m.StatusOptions)
+}
+
+// selectedFile returns the name of the file under the cursor of FileView, or
+// "" when the cursor isn't on a file.
+func selectedFile(m model.Model) string {
+	if m.CurrentView != model.FileView || m.TreeDir != "" || m.Cursor >= len(m.Files) {
+		return ""
+	}
+	return m.Files[m.Cursor].Name
+}
+
+// keepFileCursor keeps the cursor of FileView on the file name once the
+// changed files were loaded again, as staging can move it in the list.
+func keepFileCursor(m model.Model, name string) model.Model {
+	for i, file := range m.Files {
+		if file.Name == name {
+			m.Cursor = i
+			break
+		}
+	}
+	return m
+}
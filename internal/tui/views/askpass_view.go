@@ -0,0 +1,38 @@
+package view
+
+import (
+	"strings"
+
+	"froggit/internal/tui/controls"
+	"froggit/internal/tui/model"
+	"froggit/internal/tui/styles"
+)
+
+func RenderAskpassView(m model.Model) string {
+	var s strings.Builder
+
+	s.WriteString(styles.HeaderStyle.Render("🔑 Authentication required") + "\n\n")
+
+	prompt := "Git is asking for credentials:"
+	secret := true
+	if m.AskpassRequest != nil {
+		prompt = m.AskpassRequest.Prompt
+		secret = m.AskpassRequest.Secret
+	}
+	s.WriteString(styles.NormalStyle.Render(prompt) + "\n")
+
+	input := m.AskpassInput
+	if secret {
+		input = strings.Repeat("•", len([]rune(m.AskpassInput)))
+	}
+	s.WriteString(styles.InputStyle.Render(input+"_") + "\n\n")
+
+	if secret {
+		s.WriteString(styles.HelpStyle.Render("Input is hidden and is only sent to the waiting git process.") + "\n")
+	}
+
+	controlsWidget := controls.NewAskpassViewControls()
+	s.WriteString(controlsWidget.Render())
+
+	return s.String()
+}
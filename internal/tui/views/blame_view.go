@@ -0,0 +1,110 @@
+package view
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"froggit/internal/git"
+	"froggit/internal/tui/controls"
+	"froggit/internal/tui/model"
+	"froggit/internal/tui/styles"
+	"froggit/internal/utils"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// blameAges colors the commit details of a blame line by age, from changes
+// of the last day to those older than a year.
+var blameAges = []struct {
+	age   time.Duration
+	style lipgloss.Style
+}{
+	{24 * time.Hour, lipgloss.NewStyle().Foreground(lipgloss.Color(styles.Green)).Bold(true)},
+	{7 * 24 * time.Hour, lipgloss.NewStyle().Foreground(lipgloss.Color(styles.Cyan))},
+	{30 * 24 * time.Hour, lipgloss.NewStyle().Foreground(lipgloss.Color(styles.DarkGreen))},
+	{365 * 24 * time.Hour, lipgloss.NewStyle().Foreground(lipgloss.Color(styles.GrayMid))},
+}
+
+var (
+	blameOldStyle         = lipgloss.NewStyle().Foreground(lipgloss.Color(styles.GrayDark))
+	blameUncommittedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(styles.Orange))
+)
+
+func blameAgeStyle(line git.BlameLine, now time.Time) lipgloss.Style {
+	if line.Uncommitted() {
+		return blameUncommittedStyle
+	}
+	for _, a := range blameAges {
+		if now.Sub(line.Date) < a.age {
+			return a.style
+		}
+	}
+	return blameOldStyle
+}
+
+// RenderBlameView shows each line of a file with the commit, author and date
+// that last changed it. The details are only written on the first line of a
+// run of lines from the same commit.
+func RenderBlameView(m model.Model) string {
+	var sb strings.Builder
+
+	title := "  Blame: " + m.Blame.Path
+	if m.Blame.Rev != "" {
+		title += " at " + shortSHA(m.Blame.Rev)
+	}
+	top := styles.HeaderStyle.Render(title) + "\n\n"
+
+	summary := ""
+	if m.BlameCursor < len(m.BlameLines) {
+		line := m.BlameLines[m.BlameCursor]
+		summary = fmt.Sprintf("%s %s, %s", shortSHA(line.Hash), line.Summary, utils.RelativeTime(line.Date, time.Now()))
+		if line.Uncommitted() {
+			summary = "not committed yet"
+		}
+	}
+	bottom := "\n" + styles.HelpStyle.Render(fmt.Sprintf("%d/%d  %s", m.BlameCursor+1, len(m.BlameLines), summary)) +
+		"\n" + controls.NewBlameViewControls(len(m.BlameStack) > 0).Render()
+	viewport := listHeight(m, top, bottom, 15)
+
+	sb.WriteString(top)
+	total := len(m.BlameLines)
+	if total == 0 {
+		sb.WriteString(styles.HelpStyle.Render("The file is empty") + "\n")
+	}
+
+	start := 0
+	if m.BlameCursor >= viewport/2 {
+		start = m.BlameCursor - viewport/2
+	}
+	if start+viewport > total {
+		start = max(0, total-viewport)
+	}
+	end := min(total, start+viewport)
+
+	now := time.Now()
+	numWidth := len(fmt.Sprint(total))
+	for i := start; i < end; i++ {
+		line := m.BlameLines[i]
+		details := strings.Repeat(" ", 7+1+14+1+10)
+		if i == start || m.BlameLines[i-1].Hash != line.Hash {
+			author, date := line.Author, line.Date.Format("2006-01-02")
+			if line.Uncommitted() {
+				author, date = "uncommitted", ""
+			}
+			details = fmt.Sprintf("%-7s %-14s %-10s", shortSHA(line.Hash), ansi.Truncate(author, 14, "…"), date)
+		}
+
+		number := fmt.Sprintf("%*d", numWidth, i+1)
+		// selected rows are padded by their style
+		row := " " + blameAgeStyle(line, now).Render(details) + " " + styles.HelpStyle.Render(number+" │") + " " + line.Text
+		if i == m.BlameCursor {
+			row = styles.SelectedStyle.Render(details + " " + number + " │ " + line.Text)
+		}
+		sb.WriteString(fitWidth(m, row) + "\n")
+	}
+
+	sb.WriteString(bottom)
+	return sb.String()
+}
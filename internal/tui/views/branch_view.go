@@ -3,20 +3,49 @@ package view
 import (
 	"fmt"
 	"strings"
+	"time"
 
+	"froggit/internal/git"
 	"froggit/internal/tui/controls"
 	"froggit/internal/tui/model"
 	"froggit/internal/tui/styles"
+	"froggit/internal/utils"
 )
 
+// staleBranchAge is how old the last commit must be for a branch to be marked stale.
+const staleBranchAge = 90 * 24 * time.Hour
+
 func RenderBranchView(m model.Model) string {
 	var s strings.Builder
 
-	s.WriteString(styles.HeaderStyle.Render("Branches:") + "\n\n")
+	top := styles.HeaderStyle.Render("Branches:") + "\n\n" + renderFilterBar(m)
+	if m.FilterActive() && len(utils.VisibleIndices(&m)) == 0 {
+		top += renderNoMatches(m)
+	}
+	controlsWidget := controls.NewBranchViewControls()
+	bottom := "\n" + controlsWidget.Render()
+
+	// rows are the lines of the list, group headers included; selected is
+	// the one of the cursor
+	var rows []string
+	selected := 0
+	if len(m.RemoteBranches) > 0 {
+		rows = append(rows, styles.SubHeaderStyle.Render("  ── Local ──"))
+	}
 
+	nameWidth := 0
+	for _, branch := range m.Branches {
+		nameWidth = max(nameWidth, len(branch))
+	}
+
+	now := time.Now()
 	for i, branch := range m.Branches {
+		if !utils.IsVisible(&m, i) {
+			continue
+		}
 		cursor := "  "
 		if m.Cursor == i {
+			selected = len(rows)
 			cursor = ""
 		}
 
@@ -30,12 +59,87 @@ func RenderBranchView(m model.Model) string {
 			style = styles.SelectedStyle
 		}
 
-		line := fmt.Sprintf("%s %s %s", cursor, current, branch)
-		s.WriteString(style.Render(line) + "\n")
+		name := highlightMatches(m, branch) + strings.Repeat(" ", nameWidth-len(branch))
+		line := fmt.Sprintf("%s %s %s", cursor, current, name)
+		info, ok := m.BranchInfo(i)
+		if !ok {
+			rows = append(rows, rowZone(i, style.Render(line)))
+			continue
+		}
+
+		stale := !info.LastCommit.IsZero() && now.Sub(info.LastCommit) > staleBranchAge
+		if stale && m.Cursor != i {
+			style = styles.HelpStyle
+		}
+
+		details := fmt.Sprintf("  %s", utils.RelativeTime(info.LastCommit, now))
+		if info.Author != "" {
+			details += " · " + info.Author
+		}
+		if stale {
+			details += " · stale"
+		}
+
+		rows = append(rows, rowZone(i, style.Render(line)+" "+renderTracking(info)+styles.HelpStyle.Render(details)))
 	}
+	rows, selected = appendRemoteBranches(m, rows, selected)
 
-	controlsWidget := controls.NewBranchViewControls()
-	s.WriteString("\n" + controlsWidget.Render())
+	s.WriteString(top)
+	start, end := listWindow(selected, len(rows), listHeight(m, top, bottom, 15))
+	for _, row := range rows[start:end] {
+		s.WriteString(row + "\n")
+	}
+	s.WriteString(bottom)
 
 	return s.String()
 }
+
+// renderTracking describes the upstream of a branch and how far it is ahead/behind.
+func renderTracking(info git.BranchInfo) string {
+	switch {
+	case info.Upstream == "":
+		return styles.HelpStyle.Render("no upstream")
+	case info.Gone:
+		return styles.WarningStyle.Render("⚠ " + info.Upstream + " gone")
+	case info.Ahead == 0 && info.Behind == 0:
+		return styles.GraphSymbolStyle.Render("→ "+info.Upstream) + " " + styles.AddedFileStyle.Render("✓")
+	}
+
+	tracking := styles.GraphSymbolStyle.Render("→ " + info.Upstream)
+	if info.Ahead > 0 {
+		tracking += " " + styles.AddedFileStyle.Render(fmt.Sprintf("↑%d", info.Ahead))
+	}
+	if info.Behind > 0 {
+		tracking += " " + styles.ModifiedFileStyle.Render(fmt.Sprintf("↓%d", info.Behind))
+	}
+	return tracking
+}
+
+// appendRemoteBranches adds the rows of the remote-tracking branches, grouped
+// by remote, and moves selected to the row of the cursor when it is on one.
+// They follow the local branches, so cursor positions continue from there.
+func appendRemoteBranches(m model.Model, rows []string, selected int) ([]string, int) {
+	lastRemote := ""
+	for i, branch := range m.RemoteBranches {
+		row := len(m.Branches) + i
+		if !utils.IsVisible(&m, row) {
+			continue
+		}
+		if branch.Remote != lastRemote {
+			lastRemote = branch.Remote
+			rows = append(rows, styles.SubHeaderStyle.Render("  ── Remote: "+branch.Remote+" ──"))
+		}
+
+		cursor := "  "
+		style := styles.NormalStyle
+		if m.Cursor == row {
+			selected = len(rows)
+			cursor = ""
+			style = styles.SelectedStyle
+		}
+
+		line := fmt.Sprintf("%s   %s", cursor, highlightMatches(m, branch.Ref()))
+		rows = append(rows, rowZone(row, style.Render(line)))
+	}
+	return rows, selected
+}
@@ -0,0 +1,51 @@
+package view
+
+import (
+	"fmt"
+	"strings"
+
+	"froggit/internal/tui/controls"
+	"froggit/internal/tui/model"
+	"froggit/internal/tui/styles"
+)
+
+func RenderCleanupView(m model.Model) string {
+	var s strings.Builder
+
+	s.WriteString(styles.HeaderStyle.Render("🧹 Clean up branches:") + "\n\n")
+	s.WriteString(styles.HelpStyle.Render(fmt.Sprintf("  Branches merged into %s or whose upstream is gone", m.CleanupBase)) + "\n\n")
+
+	for i, c := range m.CleanupCandidates {
+		cursor := "  "
+		style := styles.NormalStyle
+		if i == m.Cursor {
+			cursor = "❯ "
+			style = styles.SelectedStyle
+		}
+
+		checked := " "
+		if c.Selected {
+			checked = "✓"
+		}
+
+		var reasons []string
+		if c.Merged {
+			reasons = append(reasons, "merged into "+m.CleanupBase)
+		}
+		if c.Gone {
+			reasons = append(reasons, "upstream gone")
+		}
+
+		line := fmt.Sprintf("%s[%s] %s", cursor, checked, c.Name)
+		reason := styles.HelpStyle.Render("  " + strings.Join(reasons, ", "))
+		if !c.Merged {
+			reason = styles.WarningStyle.Render("  " + strings.Join(reasons, ", ") + " · unmerged")
+		}
+		s.WriteString(style.Render(line) + reason + "\n")
+	}
+
+	controlsWidget := controls.NewCleanupViewControls()
+	s.WriteString("\n" + controlsWidget.Render())
+
+	return s.String()
+}
@@ -0,0 +1,135 @@
+package view
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"froggit/internal/git"
+	"froggit/internal/tui/controls"
+	"froggit/internal/tui/model"
+	"froggit/internal/tui/styles"
+	"froggit/internal/utils"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// RenderCompareView shows the ref pickers, or the commits each of the two
+// refs has that the other lacks followed by the files the target changed.
+func RenderCompareView(m model.Model) string {
+	if m.ComparePicking {
+		return renderComparePicker(m)
+	}
+
+	var sb strings.Builder
+	base, target := m.CompareRefs[model.CompareFieldBase], m.CompareRefs[model.CompareFieldTarget]
+	top := styles.HeaderStyle.Render("⇄ Compare "+base+"..."+target) + "\n\n"
+
+	// rows holds every line of the list, cursorRow the one of CompareCursor
+	var rows []string
+	cursorRow, row := 0, 0
+	now := time.Now()
+	addCommits := func(title string, commits []git.CommitInfo) {
+		rows = append(rows, styles.SubHeaderStyle.Render(title))
+		if len(commits) == 0 {
+			rows = append(rows, styles.HelpStyle.Render("  none"))
+		}
+		for _, commit := range commits {
+			age := fmt.Sprintf("%-15s", utils.RelativeTime(commit.Date, now))
+			author := fmt.Sprintf("%-14s", ansi.Truncate(commit.Author, 14, "…"))
+			if row == m.CompareCursor {
+				cursorRow = len(rows)
+				line := fmt.Sprintf("❯ %s %s %s %s", shortSHA(commit.Hash), age, author, commit.Summary)
+				rows = append(rows, styles.SelectedStyle.Render(line))
+			} else {
+				line := fmt.Sprintf("  %s %s %s %s", styles.CommitHashStyle.Render(shortSHA(commit.Hash)),
+					styles.HelpStyle.Render(age), author, commit.Summary)
+				rows = append(rows, styles.NormalStyle.Render(line))
+			}
+			row++
+		}
+		rows = append(rows, "")
+	}
+	addCommits(fmt.Sprintf("Commits on %s not on %s (%d)", target, base, len(m.CompareAhead)), m.CompareAhead)
+	addCommits(fmt.Sprintf("Commits on %s not on %s (%d)", base, target, len(m.CompareBehind)), m.CompareBehind)
+
+	rows = append(rows, styles.SubHeaderStyle.Render(fmt.Sprintf("Files changed on %s (%d)", target, len(m.CompareFiles))))
+	if len(m.CompareFiles) == 0 {
+		rows = append(rows, styles.HelpStyle.Render("  none"))
+	}
+	for _, file := range m.CompareFiles {
+		name := file.Path
+		if file.OldPath != "" {
+			name = file.OldPath + " → " + file.Path
+		}
+		selected := row == m.CompareCursor
+		style := getFileStatusStyle(git.FileItem{Status: file.Status}, selected)
+		cursor := "  "
+		if selected {
+			cursorRow = len(rows)
+			cursor = "❯ "
+		}
+		rows = append(rows, style.Render(cursor+file.Status+" "+name))
+		row++
+	}
+
+	bottom := "\n" + styles.HelpStyle.Render(fmt.Sprintf("%d/%d", min(m.CompareCursor+1, row), row)) +
+		"\n" + controls.NewCompareViewControls(false).Render()
+	viewport := listHeight(m, top, bottom, 20)
+
+	start := 0
+	if cursorRow >= viewport/2 {
+		start = cursorRow - viewport/2
+	}
+	if start+viewport > len(rows) {
+		start = max(0, len(rows)-viewport)
+	}
+	end := min(len(rows), start+viewport)
+
+	sb.WriteString(top)
+	for _, line := range rows[start:end] {
+		sb.WriteString(fitWidth(m, line) + "\n")
+	}
+	sb.WriteString(bottom)
+	return sb.String()
+}
+
+// renderComparePicker shows the base and target refs with the branches and
+// tags matching the one being picked.
+func renderComparePicker(m model.Model) string {
+	var sb strings.Builder
+
+	top := styles.HeaderStyle.Render("⇄ Compare refs") + "\n\n"
+	for field, label := range []string{"Base:  ", "Target:"} {
+		if field == m.CompareField {
+			top += styles.SelectedStyle.Render("❯ "+label+" "+m.CompareRefs[field]+"_") + "\n"
+		} else {
+			top += styles.NormalStyle.Render("  "+label+" "+m.CompareRefs[field]) + "\n"
+		}
+	}
+	top += styles.HelpStyle.Render("  a branch, a tag or a commit; the target's changes are shown since it forked from the base") + "\n\n"
+
+	bottom := "\n" + controls.NewCompareViewControls(true).Render()
+	viewport := listHeight(m, top, bottom, 10)
+
+	matches := m.CompareRefMatches()
+	sb.WriteString(top)
+	if len(matches) == 0 {
+		sb.WriteString(styles.HelpStyle.Render("  no matching branch or tag, [enter] uses it as typed") + "\n")
+	}
+	start := 0
+	if m.CompareRefCursor >= viewport {
+		start = m.CompareRefCursor - viewport + 1
+	}
+	end := min(len(matches), start+viewport)
+	for i := start; i < end; i++ {
+		if i == m.CompareRefCursor {
+			sb.WriteString(fitWidth(m, styles.SelectedStyle.Render("❯ "+matches[i])) + "\n")
+		} else {
+			sb.WriteString(fitWidth(m, styles.NormalStyle.Render("  "+matches[i])) + "\n")
+		}
+	}
+
+	sb.WriteString(bottom)
+	return sb.String()
+}
@@ -18,6 +18,31 @@ func RenderConfirmDialog(m model.Model) string {
 		icon = "🗑️"
 		title = "Delete Branch"
 		message = fmt.Sprintf("Are you sure you want to delete branch '%s'?", styles.WarningStyle.Render(m.DialogTarget))
+	case "force_delete_branch":
+		icon = "🔥"
+		title = "Force Delete Branch"
+		into := m.CurrentBranch
+		if len(m.CleanupForce) > 0 {
+			into = m.CleanupBase
+		}
+		message = fmt.Sprintf("'%s' has commits not merged into %s and they may be lost.\nType the branch name to force delete it:", styles.WarningStyle.Render(m.DialogTarget), into)
+		if len(m.CleanupForce) > 1 {
+			message += styles.HelpStyle.Render(fmt.Sprintf("\n(%d more unmerged branches to confirm after this one, [esc] skips them all)", len(m.CleanupForce)-1))
+		}
+	case "cleanup_branches":
+		icon = "🧹"
+		title = "Clean Up Branches"
+		var names []string
+		for _, c := range m.CleanupCandidates {
+			if c.Selected {
+				names = append(names, c.Name)
+			}
+		}
+		message = fmt.Sprintf("Delete %s?\n  %s", styles.WarningStyle.Render(m.DialogTarget), strings.Join(names, "\n  "))
+	case "delete_remote_branch":
+		icon = "🗑️"
+		title = "Delete Remote Branch"
+		message = fmt.Sprintf("Are you sure you want to delete '%s' on the remote?", styles.WarningStyle.Render(m.DialogTarget))
 	case "delete_remote":
 		icon = "🔗"
 		title = "Remove Remote"
@@ -26,6 +51,22 @@ func RenderConfirmDialog(m model.Model) string {
 		icon = "⚠️"
 		title = "Discard Changes"
 		message = fmt.Sprintf("Are you sure you want to discard changes in '%s'?", styles.WarningStyle.Render(m.DialogTarget))
+	case "discard_dir":
+		icon = "⚠️"
+		title = "Discard Changes"
+		message = fmt.Sprintf("Are you sure you want to discard changes in every file under '%s/'?", styles.WarningStyle.Render(m.DialogTarget))
+	case "discard_marked":
+		icon = "⚠️"
+		title = "Discard Changes"
+		message = fmt.Sprintf("Are you sure you want to discard changes in the %s?", styles.WarningStyle.Render(m.DialogTarget))
+	case "restore_revision":
+		icon = "⏪"
+		title = "Restore File"
+		message = fmt.Sprintf("Replace the worktree copy of %s? Local changes to it are lost.", styles.WarningStyle.Render(m.DialogTarget))
+	case "checkout_revision":
+		icon = "⏪"
+		title = "Check Out File"
+		message = fmt.Sprintf("Check out %s into the worktree and the index? Local changes to it are lost.", styles.WarningStyle.Render(m.DialogTarget))
 	case "drop_stash":
 		icon = "💥"
 		title = "Drop Stash"
@@ -40,9 +81,15 @@ func RenderConfirmDialog(m model.Model) string {
 
 	s.WriteString(styles.HeaderStyle.Render(icon + " " + title) + "\n\n")
 	s.WriteString(styles.NormalStyle.Render(message) + "\n")
+	if m.DialogType == "force_delete_branch" {
+		s.WriteString(styles.InputStyle.Render(m.ConfirmInput+"_") + "\n")
+	}
 	s.WriteString(styles.HelpStyle.Render("This action cannot be undone.") + "\n\n")
 
 	controlsWidget := controls.NewConfirmDialogControls()
+	if m.DialogType == "force_delete_branch" {
+		controlsWidget = controls.NewTypedConfirmControls()
+	}
 	s.WriteString(controlsWidget.Render())
 
 	return s.String()
@@ -0,0 +1,206 @@
+package view
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"froggit/internal/tui/controls"
+	"froggit/internal/tui/icons"
+	"froggit/internal/tui/model"
+	"froggit/internal/tui/styles"
+	"froggit/internal/tui/syntax"
+	"froggit/internal/tui/zone"
+	"froggit/internal/utils"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// panelNames are the dashboard panel titles, indexed by model.Panel*.
+var panelNames = [model.PanelCount]string{"Files", "Branches", "Stash", "Commits"}
+
+// panelWeights share the height of the left column between the panels.
+var panelWeights = [model.PanelCount]int{3, 2, 1, 2}
+
+// RenderDashboard renders the files, branches, stash and commits panels in a
+// column beside a main panel with the details of the focused selection.
+func RenderDashboard(m model.Model) string {
+	width := m.Width
+	if width <= 0 {
+		width = 100
+	}
+	height := m.ContentHeight
+	if height <= 0 {
+		height = 30
+	}
+
+	controlsWidget := controls.NewDashboardControls(strings.ToLower(panelNames[m.Panel]), m.AdvancedMode)
+	bottom := controlsWidget.Render()
+	available := max(model.PanelCount*3, height-1-lipgloss.Height(bottom))
+
+	leftWidth := max(32, width*2/5)
+	rightWidth := max(20, width-leftWidth)
+
+	var panels []string
+	used := 0
+	for panel := model.PanelCount - 1; panel >= 0; panel-- {
+		h := max(3, available*panelWeights[panel]/8)
+		if panel == model.PanelFiles {
+			h = max(3, available-used)
+		}
+		used += h
+		panels = append([]string{renderDashboardPanel(m, panel, leftWidth, h)}, panels...)
+	}
+
+	left := lipgloss.JoinVertical(lipgloss.Left, panels...)
+	right := renderDetailPanel(m, rightWidth, available)
+	return lipgloss.JoinHorizontal(lipgloss.Top, left, right) + "\n" + bottom
+}
+
+// panelModel returns m as the view of panel sees it: with the panel's own
+// cursor when it is not focused.
+func panelModel(m model.Model, panel int) model.Model {
+	if panel == m.Panel && m.CurrentView == model.PanelView(panel) {
+		return m
+	}
+	m.CurrentView = model.PanelView(panel)
+	m.Cursor = m.PanelCursors[panel]
+	return m
+}
+
+func renderDashboardPanel(m model.Model, panel, width, height int) string {
+	focused := panel == m.Panel
+	pm := panelModel(m, panel)
+
+	// indices are the items listed in the panel; row renders one of them
+	var (
+		indices []int
+		row     func(idx int) string
+	)
+	switch panel {
+	case model.PanelFiles:
+		indices = utils.VisibleIndices(&pm)
+		row = func(idx int) string {
+			file := pm.Files[idx]
+			staged := " "
+			if file.Staged {
+				staged = "✓"
+			}
+			line := fmt.Sprintf("[%s] %s %s %s", staged, getFileStatusIndicator(file), icons.GetIconForFile(file.Name), highlightMatches(pm, file.Name))
+			return getFileStatusStyle(file, false).Render(line)
+		}
+	case model.PanelBranches:
+		indices = utils.VisibleIndices(&pm)
+		row = func(idx int) string {
+			if idx >= len(pm.Branches) {
+				return styles.HelpStyle.Render("  " + highlightMatches(pm, pm.RemoteBranches[idx-len(pm.Branches)].Ref()))
+			}
+			branch := pm.Branches[idx]
+			current := " "
+			if branch == pm.CurrentBranch {
+				current = "●"
+			}
+			line := current + " " + highlightMatches(pm, branch)
+			if info, ok := pm.BranchInfo(idx); ok && info.Upstream != "" {
+				line += " " + renderTracking(info)
+			}
+			return line
+		}
+	case model.PanelStash:
+		indices = utils.VisibleIndices(&pm)
+		row = func(idx int) string {
+			return highlightMatches(pm, parseStashInfo(pm.Stashes[idx]))
+		}
+	case model.PanelCommits:
+		lines := m.RecentCommits
+		if focused {
+			lines = m.LogLines
+		}
+		indices = make([]int, len(lines))
+		for i := range lines {
+			indices[i] = i
+		}
+		row = func(idx int) string {
+			if focused {
+				return highlightSearch(pm, lines[idx], idx)
+			}
+			return lines[idx]
+		}
+	}
+
+	selected := slices.Index(indices, pm.Cursor)
+	render := func(i int) string {
+		return zone.Mark(fmt.Sprintf("panelrow:%d:%d", panel, indices[i]), row(indices[i]))
+	}
+
+	title := fmt.Sprintf("[%d] %s", panel+1, panelNames[panel])
+	if focused && m.FilterTyping || focused && m.FilterActive() {
+		title += " /" + m.Filter
+	}
+	if focused && (m.SearchTyping || m.SearchActive()) {
+		title += " /" + m.Search
+	}
+	return zone.Mark(fmt.Sprintf("panel:%d", panel), renderPanel(title, len(indices), render, selected, focused, width, height))
+}
+
+// renderPanel draws a bordered panel of the given outer size, scrolling the
+// count rows so the selected one stays visible. Only the rows that fit are
+// rendered.
+func renderPanel(title string, count int, row func(i int) string, selected int, focused bool, width, height int) string {
+	style := styles.PanelStyle.UnsetBackground()
+	if focused {
+		style = styles.ActivePanelStyle
+	}
+	// border and margin take two columns on each side, padding one more
+	inner := max(1, width-6)
+	visible := max(0, height-3)
+
+	start := 0
+	if selected >= visible/2 {
+		start = selected - visible/2
+	}
+	if start+visible > count {
+		start = max(0, count-visible)
+	}
+	end := min(count, start+visible)
+
+	lines := []string{styles.PanelTitleStyle.Render(ansi.Truncate(title, inner-2, "…"))}
+	for i := start; i < end; i++ {
+		marker := "  "
+		if i == selected {
+			marker = "▸ "
+		}
+		line := ansi.Truncate(marker+strings.ReplaceAll(row(i), "\t", "    "), inner, "…")
+		if i == selected && focused {
+			line = styles.SelectedStyle.UnsetPadding().Render(line)
+		}
+		lines = append(lines, line)
+	}
+	if count == 0 {
+		lines = append(lines, styles.HelpStyle.Render("  (empty)"))
+	}
+
+	return style.Width(width - 4).Height(height - 2).Render(strings.Join(lines, "\n"))
+}
+
+// renderDetailPanel shows the diff or details of the focused selection.
+func renderDetailPanel(m model.Model, width, height int) string {
+	title := m.DetailTitle
+	if title == "" {
+		title = "Details"
+	}
+
+	if len(m.DetailLines) == 0 {
+		empty := func(int) string { return styles.HelpStyle.Render("Nothing to show") }
+		return renderPanel(title, 1, empty, -1, false, width, height)
+	}
+	row := func(i int) string {
+		var code []syntax.Span
+		if i < len(m.DetailHighlights) {
+			code = m.DetailHighlights[i]
+		}
+		return renderCodeLine(m.DetailLines[i], code, nil)
+	}
+	return renderPanel(title, len(m.DetailLines), row, -1, false, width, height)
+}
@@ -0,0 +1,142 @@
+package view
+
+import (
+	"fmt"
+	"strings"
+
+	"froggit/internal/tui/model"
+	"froggit/internal/tui/styles"
+	"froggit/internal/utils"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// splitRow is a row of the side-by-side diff. Each side refers to a line of
+// DiffLines, or is blank when the other side has no counterpart.
+type splitRow struct {
+	left, right  int  // indices into DiffLines, -1 for a blank side
+	oldNo, newNo int  // line numbers in the old and new file
+	full         bool // file and hunk headers span both sides
+}
+
+// first returns the index of the first diff line shown on the row.
+func (r splitRow) first() int {
+	if r.left >= 0 {
+		return r.left
+	}
+	return r.right
+}
+
+// splitDiffRows lays a unified diff out side by side: context lines appear on
+// both sides and removed lines face the added lines that replace them.
+func splitDiffRows(lines []string) []splitRow {
+	var rows []splitRow
+	inHunk := false
+	oldNo, newNo := 0, 0
+
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		switch {
+		case strings.HasPrefix(line, "diff "):
+			inHunk = false
+		case strings.HasPrefix(line, "@@"):
+			inHunk = true
+			if oldStart, newStart, ok := utils.ParseHunkHeader(line); ok {
+				oldNo, newNo = oldStart, newStart
+			}
+		}
+
+		if !inHunk || strings.HasPrefix(line, "@@") || strings.HasPrefix(line, "\\") {
+			rows = append(rows, splitRow{left: i, right: -1, full: true})
+			i++
+			continue
+		}
+
+		if !strings.HasPrefix(line, "-") && !strings.HasPrefix(line, "+") {
+			rows = append(rows, splitRow{left: i, right: i, oldNo: oldNo, newNo: newNo})
+			oldNo++
+			newNo++
+			i++
+			continue
+		}
+
+		var removed, added []int
+		for i < len(lines) && strings.HasPrefix(lines[i], "-") {
+			removed = append(removed, i)
+			i++
+		}
+		for i < len(lines) && strings.HasPrefix(lines[i], "+") {
+			added = append(added, i)
+			i++
+		}
+		for k := 0; k < max(len(removed), len(added)); k++ {
+			row := splitRow{left: -1, right: -1}
+			if k < len(removed) {
+				row.left, row.oldNo = removed[k], oldNo
+				oldNo++
+			}
+			if k < len(added) {
+				row.right, row.newNo = added[k], newNo
+				newNo++
+			}
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+// renderSplitDiff renders viewport rows of the side-by-side diff, starting at
+// the row holding the line DiffViewOffset points at.
+func renderSplitDiff(m model.Model, viewport int) string {
+	rows := splitDiffRows(m.DiffLines)
+	start := len(rows)
+	for i, row := range rows {
+		if row.first() >= m.DiffViewOffset {
+			start = i
+			break
+		}
+	}
+	if start > len(rows)-viewport {
+		start = max(0, len(rows)-viewport)
+	}
+	end := min(len(rows), start+viewport)
+
+	width := m.Width
+	if width <= 0 {
+		width = 80
+	}
+	half := max(10, (width-3)/2)
+	separator := styles.HelpStyle.Render(" │ ")
+	pairs := utils.PairChangedLines(m.DiffLines)
+
+	var sb strings.Builder
+	for _, row := range rows[start:end] {
+		if row.full {
+			line := m.DiffLines[row.left]
+			sb.WriteString(fitWidth(m, diffLineStyle(line).Render(highlightSearch(m, line, row.left))) + "\n")
+			continue
+		}
+		left := renderSplitCell(m, pairs, row.left, row.oldNo, half)
+		right := renderSplitCell(m, pairs, row.right, row.newNo, half)
+		sb.WriteString(left + separator + right + "\n")
+	}
+	return sb.String()
+}
+
+// renderSplitCell renders one side of a row, numbered and padded to width.
+func renderSplitCell(m model.Model, pairs map[int]int, i, lineNo, width int) string {
+	if i < 0 {
+		return strings.Repeat(" ", width)
+	}
+
+	line := m.DiffLines[i]
+	text := strings.ReplaceAll(renderDiffLine(m, pairs, i), "\t", "    ")
+	// the +/- prefix is implied by the side, so drop it after styling
+	if line != "" {
+		text = ansi.Cut(text, 1, ansi.StringWidth(text))
+	}
+
+	cell := styles.HelpStyle.Render(fmt.Sprintf("%4d ", lineNo)) + text
+	cell = ansi.Truncate(cell, width, "…")
+	return cell + strings.Repeat(" ", max(0, width-ansi.StringWidth(cell)))
+}
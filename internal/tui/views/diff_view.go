@@ -7,6 +7,8 @@ import (
 	"froggit/internal/tui/controls"
 	"froggit/internal/tui/model"
 	"froggit/internal/tui/styles"
+	"froggit/internal/tui/syntax"
+	"froggit/internal/utils"
 
 	"github.com/charmbracelet/lipgloss"
 )
@@ -20,16 +22,46 @@ var (
 			Foreground(lipgloss.Color(styles.Cyan))
 )
 
-func RenderDiffView(m model.Model) string {
-	const viewport = 20
+// diffLineStyle colors added, removed and hunk header lines of a diff.
+func diffLineStyle(line string) lipgloss.Style {
+	switch {
+	case strings.HasPrefix(line, "+") && !strings.HasPrefix(line, "+++"):
+		return diffAddStyle
+	case strings.HasPrefix(line, "-") && !strings.HasPrefix(line, "---"):
+		return diffRemoveStyle
+	case strings.HasPrefix(line, "@@"):
+		return diffHunkStyle
+	}
+	return styles.NormalStyle
+}
 
+func RenderDiffView(m model.Model) string {
 	var sb strings.Builder
 
-	sb.WriteString(styles.HeaderStyle.Render("  Diff Preview:") + "\n\n")
-
 	total := len(m.DiffLines)
+	title := "  Diff Preview:"
+	if m.DiffTitle != "" {
+		title = "  " + m.DiffTitle
+	}
+	top := styles.HeaderStyle.Render(title) + " " + styles.HelpStyle.Render(diffModeSummary(m)) + "\n\n"
+	position := fmt.Sprintf("%d/%d", min(m.DiffViewOffset+1, total), total)
+	controlsWidget := controls.NewDiffViewControls(controls.DiffViewState{
+		Split:            m.DiffSplit,
+		IgnoreWhitespace: m.DiffOptions.IgnoreWhitespace,
+		Highlight:        !m.DiffPlain,
+		External:         m.DiffExternal(),
+		HasRenderer:      m.DiffRenderer.Enabled(),
+		Revision:         m.DiffRev != "",
+	})
+	bottom := "\n" + styles.HelpStyle.Render(position) + renderSearchBar(m) +
+		"\n" + controlsWidget.Render()
+	viewport := listHeight(m, top, bottom, 20)
+
+	sb.WriteString(top)
 	if total == 0 {
 		sb.WriteString(styles.HelpStyle.Render("No diff to display\n"))
+	} else if m.DiffSplit && !m.DiffExternal() {
+		sb.WriteString(renderSplitDiff(m, viewport))
 	} else {
 		start := m.DiffViewOffset
 		if start > total-viewport {
@@ -37,25 +69,161 @@ func RenderDiffView(m model.Model) string {
 		}
 		end := min(total, start+viewport)
 
+		pairs := utils.PairChangedLines(m.DiffLines)
 		for i := start; i < end; i++ {
-			line := m.DiffLines[i]
-			if strings.HasPrefix(line, "+") && !strings.HasPrefix(line, "+++") {
-				sb.WriteString(diffAddStyle.Render(line) + "\n")
-			} else if strings.HasPrefix(line, "-") && !strings.HasPrefix(line, "---") {
-				sb.WriteString(diffRemoveStyle.Render(line) + "\n")
-			} else if strings.HasPrefix(line, "@@") {
-				sb.WriteString(diffHunkStyle.Render(line) + "\n")
-			} else {
-				sb.WriteString(styles.NormalStyle.Render(line) + "\n")
-			}
+			sb.WriteString(fitWidth(m, renderDiffLine(m, pairs, i)) + "\n")
 		}
 	}
 
-	position := fmt.Sprintf("%d/%d", min(m.DiffViewOffset+1, total), total)
-	sb.WriteString("\n" + styles.HelpStyle.Render(position))
+	sb.WriteString(bottom)
 
-	controlsWidget := controls.NewDiffViewControls()
-	sb.WriteString("\n" + controlsWidget.Render())
+	return sb.String()
+}
 
+// diffModeSummary describes how the diff is shown and generated.
+func diffModeSummary(m model.Model) string {
+	if m.DiffExternal() {
+		renderer := m.DiffRenderer.Pager
+		if renderer == "" {
+			renderer = "diff.external"
+		}
+		return fmt.Sprintf("rendered by %s · %d lines of context", renderer, m.DiffOptions.ContextLines())
+	}
+	mode := "unified"
+	if m.DiffSplit {
+		mode = "split"
+	}
+	summary := fmt.Sprintf("%s · %d lines of context", mode, m.DiffOptions.ContextLines())
+	if m.DiffOptions.IgnoreWhitespace {
+		summary += " · ignoring whitespace"
+	}
+	switch {
+	case m.DiffPlain:
+		summary += " · no syntax highlighting"
+	case len(m.DiffLines) > syntax.MaxLines:
+		summary += " · too large to highlight"
+	}
+	return summary
+}
+
+// renderDiffLine colors line i of the diff. When a removed line is replaced
+// by an added one, the words that changed between them are highlighted, unless
+// the line matches the search, which then shows its matches instead.
+func renderDiffLine(m model.Model, pairs map[int]int, i int) string {
+	line := m.DiffLines[i]
+	if m.DiffExternal() {
+		// external renderers color the diff themselves
+		return line
+	}
+	if m.SearchActive() && utils.CompileSearch(m.Search).MatchString(line) {
+		return diffLineStyle(line).Render(highlightSearch(m, line, i))
+	}
+
+	var words []utils.DiffSpan
+	if partner, paired := pairs[i]; paired {
+		words = wordSpans(line, m.DiffLines[partner])
+	}
+	var code []syntax.Span
+	if i < len(m.DiffHighlights) {
+		code = m.DiffHighlights[i]
+	}
+	return renderCodeLine(line, code, words)
+}
+
+// wordSpans splits the text of a changed line after its +/- prefix into the
+// words it shares with its partner line and the words that changed.
+func wordSpans(line, partner string) []utils.DiffSpan {
+	if strings.HasPrefix(line, "-") {
+		spans, _ := utils.WordDiff(line[1:], partner[1:])
+		return spans
+	}
+	_, spans := utils.WordDiff(partner[1:], line[1:])
+	return spans
+}
+
+// renderCodeLine renders a diff line from its highlighted code, tinting the
+// background of added and removed lines and, more strongly, of the changed
+// words. Without highlighting the line is colored by its prefix alone.
+func renderCodeLine(line string, code []syntax.Span, words []utils.DiffSpan) string {
+	style := diffLineStyle(line)
+	if code == nil {
+		if words == nil {
+			return style.Render(line)
+		}
+		code = []syntax.Span{{Text: line[1:]}}
+	} else {
+		style = style.Background(diffTint(line, false))
+	}
+
+	var sb strings.Builder
+	sb.WriteString(style.Render(line[:1]))
+	for _, piece := range overlayWords(code, words) {
+		pieceStyle := style
+		if piece.Color != "" {
+			pieceStyle = pieceStyle.Foreground(lipgloss.Color(piece.Color))
+		}
+		if piece.changed {
+			pieceStyle = pieceStyle.Background(diffTint(line, true)).Bold(true)
+		} else if piece.Bold {
+			pieceStyle = pieceStyle.Bold(true)
+		}
+		sb.WriteString(pieceStyle.Render(piece.Text))
+	}
 	return sb.String()
 }
+
+// diffTint is the background of added and removed lines, or of the words
+// that changed within them.
+func diffTint(line string, changed bool) lipgloss.TerminalColor {
+	switch {
+	case strings.HasPrefix(line, "+") && changed:
+		return lipgloss.Color("28")
+	case strings.HasPrefix(line, "+"):
+		return lipgloss.Color("22")
+	case strings.HasPrefix(line, "-") && changed:
+		return lipgloss.Color("88")
+	case strings.HasPrefix(line, "-"):
+		return lipgloss.Color("52")
+	}
+	return lipgloss.NoColor{}
+}
+
+// codePiece is a run of highlighted code that either changed or did not.
+type codePiece struct {
+	syntax.Span
+	changed bool
+}
+
+// overlayWords cuts highlighted code wherever a changed word of the word diff
+// starts or ends, so both can be shown at once.
+func overlayWords(code []syntax.Span, words []utils.DiffSpan) []codePiece {
+	var pieces []codePiece
+	w, wordLeft := 0, 0
+	if len(words) > 0 {
+		wordLeft = len(words[0].Text)
+	}
+	for _, span := range code {
+		text := span.Text
+		for text != "" {
+			if w >= len(words) {
+				pieces = append(pieces, codePiece{Span: syntax.Span{Text: text, Color: span.Color, Bold: span.Bold}})
+				break
+			}
+			if wordLeft == 0 {
+				w++
+				if w < len(words) {
+					wordLeft = len(words[w].Text)
+				}
+				continue
+			}
+			n := min(len(text), wordLeft)
+			pieces = append(pieces, codePiece{
+				Span:    syntax.Span{Text: text[:n], Color: span.Color, Bold: span.Bold},
+				changed: words[w].Changed,
+			})
+			text = text[n:]
+			wordLeft -= n
+		}
+	}
+	return pieces
+}
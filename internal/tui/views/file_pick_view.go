@@ -0,0 +1,48 @@
+package view
+
+import (
+	"fmt"
+	"strings"
+
+	"froggit/internal/tui/controls"
+	"froggit/internal/tui/model"
+	"froggit/internal/tui/styles"
+)
+
+// RenderFilePickView shows the path being typed and the tracked files
+// matching it.
+func RenderFilePickView(m model.Model) string {
+	var sb strings.Builder
+
+	title := "  Blame a file"
+	if m.FilePickFor == model.HistoryView {
+		title = "  History of a file"
+	}
+	top := styles.HeaderStyle.Render(title) + "\n\n" +
+		styles.SelectedStyle.Render("❯ Path: "+m.FilePickInput+"_") + "\n\n"
+
+	matches := m.FilePickMatches()
+	position := fmt.Sprintf("%d/%d", min(m.FilePickCursor+1, len(matches)), len(matches))
+	bottom := "\n" + styles.HelpStyle.Render(position) + "\n" + controls.NewFilePickViewControls().Render()
+	viewport := listHeight(m, top, bottom, 15)
+
+	sb.WriteString(top)
+	if len(matches) == 0 {
+		sb.WriteString(styles.HelpStyle.Render("  no tracked file matches") + "\n")
+	}
+	start := 0
+	if m.FilePickCursor >= viewport {
+		start = m.FilePickCursor - viewport + 1
+	}
+	end := min(len(matches), start+viewport)
+	for i := start; i < end; i++ {
+		if i == m.FilePickCursor {
+			sb.WriteString(fitWidth(m, styles.SelectedStyle.Render("❯ "+matches[i])) + "\n")
+		} else {
+			sb.WriteString(fitWidth(m, styles.NormalStyle.Render("  "+matches[i])) + "\n")
+		}
+	}
+
+	sb.WriteString(bottom)
+	return sb.String()
+}
@@ -9,6 +9,8 @@ import (
 	"froggit/internal/tui/icons"
 	"froggit/internal/tui/model"
 	"froggit/internal/tui/styles"
+	"froggit/internal/tui/zone"
+	"froggit/internal/utils"
 
 	"github.com/charmbracelet/lipgloss"
 )
@@ -66,22 +68,32 @@ func getFileStatusStyle(file git.FileItem, isSelected bool) lipgloss.Style {
 	}
 }
 
-func RenderFileView(m model.Model) string {
-	var s strings.Builder
-
-	stagedCount := 0
-	unstagedCount := 0
+// fileCounts returns how many files are staged and unstaged.
+func fileCounts(m model.Model) (staged, unstaged int) {
 	for _, file := range m.Files {
 		if file.Staged {
-			stagedCount++
+			staged++
 		} else {
-			unstagedCount++
+			unstaged++
 		}
 	}
+	return staged, unstaged
+}
 
+// fileViewTop renders the status summary above the file list.
+func fileViewTop(m model.Model) string {
+	var s strings.Builder
+
+	stagedCount, unstagedCount := fileCounts(m)
 	s.WriteString(styles.HeaderStyle.Render("  Git Status:") + "\n")
 	s.WriteString(fmt.Sprintf("  Staged: %d files\n", stagedCount))
 	s.WriteString(fmt.Sprintf("  Unstaged: %d files\n", unstagedCount))
+	if m.StatusOptions.SkipUntracked {
+		s.WriteString(styles.HelpStyle.Render("  Untracked files are not listed, press U to list them") + "\n")
+	}
+	if marked := len(m.MarkedFiles()); marked > 0 {
+		s.WriteString(styles.WarningStyle.Render(fmt.Sprintf("  Marked: %d files", marked)) + "\n")
+	}
 
 	if m.HasRemoteChanges {
 		s.WriteString(styles.WarningStyle.Render("  New commits are available on the remote please pull\n"))
@@ -89,10 +101,57 @@ func RenderFileView(m model.Model) string {
 	s.WriteString("\n")
 	s.WriteString(styles.HeaderStyle.Render(" Modified files:") + "\n\n")
 
+	s.WriteString(renderFilterBar(m))
+	return s.String()
+}
+
+// fileViewBottom renders the controls below the file list.
+func fileViewBottom(m model.Model) string {
+	stagedCount, _ := fileCounts(m)
+	controlsWidget := controls.NewFileViewControls(controls.FileViewState{
+		Staged:          stagedCount > 0,
+		HasFiles:        len(m.Files) > 0,
+		Advanced:        m.AdvancedMode,
+		UntrackedHidden: m.StatusOptions.SkipUntracked,
+		Marked:          len(m.MarkedFiles()),
+		Untracked:       m.TreeDir == "" && m.Cursor < len(m.Files) && m.Files[m.Cursor].Status == "??",
+	})
+	return "\n" + controlsWidget.Render()
+}
+
+// LayoutFileView sizes the file list to the rows left between the status
+// summary and the controls, keeping two for the "more above/below"
+// indicators, scrolls the cursor into it and renders the view. The summary
+// and controls are rendered once for both.
+func LayoutFileView(m model.Model) (model.Model, string) {
+	top, bottom := fileViewTop(m), fileViewBottom(m)
+	if m.ContentHeight > 0 {
+		m.FileViewHeight = max(1, listHeight(m, top, bottom, 10)-2)
+		utils.EnsureFileVisible(&m)
+	}
+	return m, renderFileView(m, top, bottom)
+}
+
+func renderFileView(m model.Model, top, bottom string) string {
+	var s strings.Builder
+
+	s.WriteString(top)
+
+	// Staged files first, then unstaged, skipping those hidden by the filter
+	rows := utils.NewFileRows(&m)
+	count := rows.Len()
+	renderRow := func(row int) string { return renderFileRow(m, rows, row) }
+	if m.FileTree {
+		tree := utils.FileTree(&m)
+		count = len(tree)
+		renderRow = func(row int) string { return renderTreeRow(m, tree[row]) }
+	}
+
 	if len(m.Files) == 0 {
 		s.WriteString(styles.HelpStyle.Render("No modified files\n"))
+	} else if len(rows.Order) == 0 {
+		s.WriteString(renderNoMatches(m))
 	} else {
-		totalFiles := len(m.Files)
 		viewHeight := m.FileViewHeight
 		if viewHeight <= 0 {
 			viewHeight = 15
@@ -102,76 +161,96 @@ func RenderFileView(m model.Model) string {
 			s.WriteString(styles.HelpStyle.Render(fmt.Sprintf("  ↑ %d more above\n", m.FileViewOffset)))
 		}
 
-		endIdx := m.FileViewOffset + viewHeight
-		if endIdx > totalFiles {
-			endIdx = totalFiles
-		}
-
-		// Build ordered indices: staged first, then unstaged
-		var stagedIdx, unstagedIdx []int
-		for i := 0; i < totalFiles; i++ {
-			if m.Files[i].Staged {
-				stagedIdx = append(stagedIdx, i)
-			} else {
-				unstagedIdx = append(unstagedIdx, i)
-			}
-		}
-		orderedIdx := append(stagedIdx, unstagedIdx...)
-
-		renderedCount := 0
-		showedStagedHeader := false
-		showedUnstagedHeader := false
-
-		for _, idx := range orderedIdx {
-			file := m.Files[idx]
-
-			// Show group headers
-			if file.Staged && !showedStagedHeader && stagedCount > 0 {
-				showedStagedHeader = true
-				if renderedCount >= m.FileViewOffset && renderedCount < m.FileViewOffset+viewHeight {
-					s.WriteString(styles.SubHeaderStyle.Render("  ── Staged ──") + "\n")
-				}
-				renderedCount++
-			}
-			if !file.Staged && !showedUnstagedHeader && unstagedCount > 0 {
-				showedUnstagedHeader = true
-				if renderedCount >= m.FileViewOffset && renderedCount < m.FileViewOffset+viewHeight {
-					s.WriteString(styles.SubHeaderStyle.Render("  ── Unstaged ──") + "\n")
-				}
-				renderedCount++
-			}
-
-			if renderedCount >= m.FileViewOffset && renderedCount < m.FileViewOffset+viewHeight {
-				cursor := "  "
-				if m.Cursor == idx {
-					cursor = "▸ "
-				}
-
-				staged := " "
-				if file.Staged {
-					staged = "✓"
-				}
-
-				isSelected := m.Cursor == idx
-				style := getFileStatusStyle(file, isSelected)
-
-				icon := icons.GetIconForFile(file.Name)
-				statusIndicator := getFileStatusIndicator(file)
-				line := fmt.Sprintf("%s [%s] %s %s %s", cursor, staged, statusIndicator, icon, file.Name)
-				s.WriteString(style.Render(line) + "\n")
-			}
-			renderedCount++
+		// only the rows inside the viewport are rendered, however many files there are
+		end := min(count, m.FileViewOffset+viewHeight)
+		for row := m.FileViewOffset; row < end; row++ {
+			s.WriteString(renderRow(row) + "\n")
 		}
 
 		// Count items below viewport
-		remaining := renderedCount - (m.FileViewOffset + viewHeight)
+		remaining := count - end
 		if remaining > 0 {
 			s.WriteString(styles.HelpStyle.Render(fmt.Sprintf("  ↓ %d more below\n", remaining)))
 		}
 	}
 
-	controlsWidget := controls.NewFileViewControls(stagedCount > 0, len(m.Files) > 0, m.AdvancedMode)
-	s.WriteString("\n" + controlsWidget.Render())
+	s.WriteString(bottom)
 
 	return s.String()
 }
+
+// renderFileRow renders a row of the flat file list: a file or the header
+// of the staged or unstaged group.
+func renderFileRow(m model.Model, rows utils.FileRows, row int) string {
+	idx, header := rows.At(row)
+	if idx < 0 {
+		return styles.SubHeaderStyle.Render("  ── " + header + " ──")
+	}
+	file := m.Files[idx]
+
+	cursor := fileCursor(file, m.Cursor == idx)
+
+	staged := " "
+	if file.Staged {
+		staged = "✓"
+	}
+
+	isSelected := m.Cursor == idx
+	style := getFileStatusStyle(file, isSelected)
+
+	icon := icons.GetIconForFile(file.Name)
+	statusIndicator := getFileStatusIndicator(file)
+	line := fmt.Sprintf("%s [%s] %s %s %s", cursor, staged, statusIndicator, icon, highlightMatches(m, file.Name))
+	return rowZone(idx, fitWidth(m, style.Render(line)))
+}
+
+// renderTreeRow renders a row of the file tree: a directory with the number
+// of changed files below it, or a file indented under its directory.
+func renderTreeRow(m model.Model, row utils.TreeRow) string {
+	indent := strings.Repeat("  ", row.Depth)
+
+	if row.File < 0 {
+		cursor := "  "
+		style := styles.SubHeaderStyle
+		if m.TreeDir == row.Dir {
+			cursor = "▸ "
+			style = styles.SelectedStyle
+		}
+		arrow := "▾"
+		if row.Collapsed {
+			arrow = "▹"
+		}
+		counts := fmt.Sprintf("%d files", row.Files)
+		if row.Files == 1 {
+			counts = "1 file"
+		}
+		if row.Staged > 0 {
+			counts += fmt.Sprintf(", %d staged", row.Staged)
+		}
+		line := fmt.Sprintf("%s%s%s %s/", cursor, indent, arrow, row.Label)
+		return zone.Mark("treedir:"+row.Dir, fitWidth(m, style.Render(line)+" "+styles.HelpStyle.Render(counts)))
+	}
+
+	file := m.Files[row.File]
+	isSelected := m.TreeDir == "" && m.Cursor == row.File
+	cursor := fileCursor(file, isSelected)
+	staged := " "
+	if file.Staged {
+		staged = "✓"
+	}
+	line := fmt.Sprintf("%s%s[%s] %s %s %s", cursor, indent, staged, getFileStatusIndicator(file), icons.GetIconForFile(file.Name), highlightMatches(m, row.Label))
+	return rowZone(row.File, fitWidth(m, getFileStatusStyle(file, isSelected).Render(line)))
+}
+
+// fileCursor returns the two columns before a file: the cursor and whether
+// the file is marked for a bulk action.
+func fileCursor(file git.FileItem, selected bool) string {
+	cursor := " "
+	if selected {
+		cursor = "▸"
+	}
+	if file.Selected {
+		return cursor + "●"
+	}
+	return cursor + " "
+}
@@ -0,0 +1,34 @@
+package view
+
+import (
+	"fmt"
+	"testing"
+
+	"froggit/internal/git"
+	"froggit/internal/tui/model"
+)
+
+// largeFileModel lists n changed files, a third of them staged.
+func largeFileModel(n int) model.Model {
+	m := model.Model{CurrentView: model.FileView, Width: 120, Height: 40, FileViewHeight: 30}
+	for i := 0; i < n; i++ {
+		m.Files = append(m.Files, git.FileItem{Name: fmt.Sprintf("dir%d/file%d.go", i%100, i), Status: "M", Staged: i%3 == 0})
+	}
+	return m
+}
+
+func BenchmarkRenderFileView(b *testing.B) {
+	m := largeFileModel(50000)
+	for i := 0; i < b.N; i++ {
+		LayoutFileView(m)
+	}
+}
+
+func BenchmarkRenderDashboard(b *testing.B) {
+	m := largeFileModel(50000)
+	m.Dashboard = true
+	m.ContentHeight = 40
+	for i := 0; i < b.N; i++ {
+		RenderDashboard(m)
+	}
+}
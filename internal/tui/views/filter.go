@@ -0,0 +1,59 @@
+package view
+
+import (
+	"fmt"
+	"strings"
+
+	"froggit/internal/tui/model"
+	"froggit/internal/tui/styles"
+	"froggit/internal/utils"
+)
+
+// renderFilterBar shows the "/" filter of the current view and how many items match.
+func renderFilterBar(m model.Model) string {
+	if !m.FilterActive() && !(m.FilterTyping && m.FilterView == m.CurrentView) {
+		return ""
+	}
+
+	query := "/" + m.Filter
+	if m.FilterTyping {
+		query = styles.InputStyle.Render(query) + styles.CursorStyle.Render("│")
+	} else {
+		query = styles.SubHeaderStyle.Render(query)
+	}
+
+	matches := len(utils.VisibleIndices(&m))
+	total := len(utils.ListItems(&m))
+	return fmt.Sprintf("  %s %s\n\n", query, styles.HelpStyle.Render(fmt.Sprintf("%d/%d", matches, total)))
+}
+
+// highlightMatches underlines the runes of text matched by the active filter.
+func highlightMatches(m model.Model, text string) string {
+	if !m.FilterActive() {
+		return text
+	}
+	positions, ok := utils.FuzzyMatch(m.Filter, text)
+	if !ok {
+		return text
+	}
+
+	matched := make(map[int]bool, len(positions))
+	for _, p := range positions {
+		matched[p] = true
+	}
+
+	var sb strings.Builder
+	for i, r := range []rune(text) {
+		if matched[i] {
+			sb.WriteString(styles.FilterMatchStyle.Render(string(r)))
+		} else {
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+// renderNoMatches is shown instead of the list when the filter hides every item.
+func renderNoMatches(m model.Model) string {
+	return styles.HelpStyle.Render(fmt.Sprintf("  No matches for %q (esc to clear)", m.Filter)) + "\n"
+}
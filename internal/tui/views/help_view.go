@@ -15,16 +15,35 @@ func RenderHelpView() string {
 	lines := []string{
 		"[a] stage all",
 		"[u] unstage all",
+		"[U] list or hide untracked files (hidden at first in large repositories)",
+		"[v] mark files, [V] mark all: [space] stages or unstages, [x] discards, [s] stashes and [i] ignores just the marked ones, [esc] clears",
+		"[i] ignore an untracked file by path, extension or directory, in .gitignore, a nested .gitignore or .git/info/exclude",
+		"[I] explain why a path is ignored (git check-ignore -v)",
+		"[T] show files as a directory tree: [enter] collapses a directory, [space]/[x] stage or discard all of its files",
 		"[b] branches",
 		"[m] remotes",
 		"[p] push",
 		"[f] fetch",
 		"[l] pull (only when remote changes)",
 		"[c] commit",
-		"[d] diff preview",
+		"[d] diff preview: [v] side by side, [w] ignore whitespace, [+]/[-] context lines, [h] syntax highlighting, [e] external renderer",
+		"[t] open the file in git's difftool, or mergetool when it has conflicts",
+		"[B] blame the file: [enter] shows the commit of a line in the log graph, [p] blames the revision before it",
+		"[H] file history, also from the diff and blame: [enter] shows a commit's change, [r]/[c] restore the file from it",
+		"[o] open the file in your editor, at the diff hunk, the blamed line or first conflict, also from merge and rebase conflicts",
 		"[x] discard changes",
 		"[r] refresh",
+		"[/] filter files, branches, stashes, repositories and the log graph",
+		"[/] search diffs, [f] search the log graph, [n]/[N] next/previous match",
+		"[A] then [L] then [s] search commits (-S, -G, --grep, --author)",
 		"[A] advanced (logs, merge, stash, rebase)",
+		"[A] then [p] push options (remote, branch, force-with-lease, tags, dry run)",
+		"[A] then [l] pull with a chosen strategy (ff-only, merge, rebase, autostash)",
+		"[A] then [S] stashes: [s] save (staged only, keep index, untracked), [b] branch from a stash, [r] rename it",
+		"[A] then [B] or [H] blame or show the history of any tracked file, picked by path",
+		"[A] then [C] compare two refs: commits each way and the changed files, [enter] drills into one, [d] diffs them all",
+		"[tab]/[1-4] focus panel (dashboard layout)",
+		"mouse: click to select, wheel to scroll, click a control to press it, double-click a file to stage/unstage",
 		"[q] quit",
 		"[esc] back",
 	}
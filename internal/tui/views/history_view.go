@@ -0,0 +1,59 @@
+package view
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"froggit/internal/tui/controls"
+	"froggit/internal/tui/model"
+	"froggit/internal/tui/styles"
+	"froggit/internal/utils"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// RenderHistoryView lists the commits that changed a file, newest first,
+// with the name the file had in commits before a rename.
+func RenderHistoryView(m model.Model) string {
+	var sb strings.Builder
+
+	total := len(m.HistoryCommits)
+	top := styles.HeaderStyle.Render("  History of "+m.HistoryPath) + "\n\n"
+	position := fmt.Sprintf("%d/%d", min(m.HistoryCursor+1, total), total)
+	bottom := "\n" + styles.HelpStyle.Render(position) + "\n" + controls.NewHistoryViewControls().Render()
+	viewport := listHeight(m, top, bottom, 15)
+
+	sb.WriteString(top)
+	start := 0
+	if m.HistoryCursor >= viewport/2 {
+		start = m.HistoryCursor - viewport/2
+	}
+	if start+viewport > total {
+		start = max(0, total-viewport)
+	}
+	end := min(total, start+viewport)
+
+	now := time.Now()
+	for i := start; i < end; i++ {
+		commit := m.HistoryCommits[i]
+		age := fmt.Sprintf("%-15s", utils.RelativeTime(commit.Date, now))
+		author := fmt.Sprintf("%-14s", ansi.Truncate(commit.Author, 14, "…"))
+		summary := commit.Summary
+		if commit.Path != m.HistoryPath {
+			summary += " (as " + commit.Path + ")"
+		}
+
+		if i == m.HistoryCursor {
+			line := fmt.Sprintf("❯ %s %s %s %s", shortSHA(commit.Hash), age, author, summary)
+			sb.WriteString(fitWidth(m, styles.SelectedStyle.Render(line)) + "\n")
+			continue
+		}
+		line := fmt.Sprintf("  %s %s %s %s", styles.CommitHashStyle.Render(shortSHA(commit.Hash)),
+			styles.HelpStyle.Render(age), author, summary)
+		sb.WriteString(fitWidth(m, styles.NormalStyle.Render(line)) + "\n")
+	}
+
+	sb.WriteString(bottom)
+	return sb.String()
+}
@@ -0,0 +1,96 @@
+package view
+
+import (
+	"fmt"
+	"strings"
+
+	"froggit/internal/tui/controls"
+	"froggit/internal/tui/model"
+	"froggit/internal/tui/styles"
+)
+
+func RenderIgnoreView(m model.Model) string {
+	var s strings.Builder
+
+	s.WriteString(styles.HeaderStyle.Render("🙈 Ignore "+m.IgnorePath) + "\n\n")
+
+	field := func(idx int, line string) {
+		cursor := "  "
+		style := styles.NormalStyle
+		if m.IgnoreField == idx {
+			cursor = "❯ "
+			style = styles.SelectedStyle
+		}
+		s.WriteString(style.Render(cursor+line) + "\n")
+	}
+
+	patterns := m.IgnorePatterns()
+	pattern := patterns[min(m.IgnorePattern, len(patterns)-1)]
+	target, dir := "", ""
+	if m.IgnoreTarget < len(m.IgnoreTargets) {
+		target = m.IgnoreTargets[m.IgnoreTarget].Label
+		dir = m.IgnoreTargets[m.IgnoreTarget].Dir
+	}
+	field(model.IgnoreFieldPattern, fmt.Sprintf("Pattern: ◂ %s ▸  %s", pattern, describeIgnorePattern(pattern, dir)))
+	field(model.IgnoreFieldFile, fmt.Sprintf("File:    ◂ %s ▸", target))
+
+	if target == ".git/info/exclude" {
+		s.WriteString("\n" + styles.HelpStyle.Render("  info/exclude only applies to this clone and isn't committed") + "\n")
+	}
+	s.WriteString("\n" + styles.HelpStyle.Render(fmt.Sprintf("  appends %q to %s", pattern, target)) + "\n")
+
+	controlsWidget := controls.NewIgnoreViewControls()
+	s.WriteString(controlsWidget.Render())
+
+	return s.String()
+}
+
+// describeIgnorePattern says in words what a pattern from IgnorePatterns
+// matches when written to an ignore file in dir.
+func describeIgnorePattern(pattern, dir string) string {
+	switch {
+	case strings.HasPrefix(pattern, "*") && dir != "":
+		return "every " + strings.TrimPrefix(pattern, "*") + " file in " + dir + "/"
+	case strings.HasPrefix(pattern, "*"):
+		return "every " + strings.TrimPrefix(pattern, "*") + " file"
+	case strings.HasSuffix(pattern, "/"):
+		return "the whole directory"
+	default:
+		return "this file only"
+	}
+}
+
+// RenderIgnoreCheckView explains whether the typed path is ignored and by
+// which pattern.
+func RenderIgnoreCheckView(m model.Model) string {
+	var s strings.Builder
+
+	s.WriteString(styles.HeaderStyle.Render("🔍 Why is it ignored?") + "\n\n")
+	s.WriteString(styles.SubHeaderStyle.Render("Path:") + "\n")
+	s.WriteString(styles.InputStyle.Render(m.IgnoreCheck+"_") + "\n\n")
+
+	match := m.IgnoreMatch
+	rule := fmt.Sprintf("%s line %d: %s", match.Source, match.Line, match.Pattern)
+	switch {
+	case m.IgnoreCheck == "" || match.Path != m.IgnoreCheck:
+	case match.Tracked && match.Source != "" && !strings.HasPrefix(match.Pattern, "!"):
+		s.WriteString(styles.WarningStyle.Render("⚠ Not ignored: the file is tracked, so ignore rules don't apply to it") + "\n")
+		s.WriteString(styles.HelpStyle.Render("  it would be ignored by "+rule) + "\n")
+		s.WriteString(styles.HelpStyle.Render("  untrack it with git rm --cached to ignore it") + "\n")
+	case match.Ignored():
+		s.WriteString(styles.ErrorStyle.Render("✗ Ignored") + "\n")
+		s.WriteString(styles.HelpStyle.Render("  by "+rule) + "\n")
+	case match.Source != "":
+		s.WriteString(styles.SuccessStyle.Render("✓ Not ignored") + "\n")
+		s.WriteString(styles.HelpStyle.Render("  re-included by "+rule) + "\n")
+	default:
+		s.WriteString(styles.SuccessStyle.Render("✓ Not ignored") + "\n")
+		s.WriteString(styles.HelpStyle.Render("  no pattern matches it") + "\n")
+	}
+	s.WriteString("\n")
+
+	controlsWidget := controls.NewIgnoreCheckViewControls()
+	s.WriteString(controlsWidget.Render())
+
+	return s.String()
+}
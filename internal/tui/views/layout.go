@@ -0,0 +1,49 @@
+package view
+
+import (
+	"strconv"
+	"strings"
+
+	"froggit/internal/tui/model"
+	"froggit/internal/tui/zone"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// listHeight returns how many rows of a scrollable list fit in the view once
+// top and bottom, the parts rendered above and below the list, are drawn.
+// Before the terminal size is known it returns fallback.
+func listHeight(m model.Model, top, bottom string, fallback int) int {
+	if m.ContentHeight <= 0 {
+		return fallback
+	}
+	return max(3, m.ContentHeight-1-strings.Count(top, "\n")-strings.Count(bottom, "\n"))
+}
+
+// listWindow returns the rows of a list of total rows shown in a viewport of
+// the given height, keeping the selected row near its middle.
+func listWindow(selected, total, viewport int) (start, end int) {
+	if selected >= viewport/2 {
+		start = selected - viewport/2
+	}
+	if start+viewport > total {
+		start = max(0, total-viewport)
+	}
+	return start, min(total, start+viewport)
+}
+
+// rowZone marks a list row so a click on it selects item idx of the current view.
+func rowZone(idx int, row string) string {
+	return zone.Mark("row:"+strconv.Itoa(idx), row)
+}
+
+// fitWidth cuts a line to the terminal width so long lines never wrap and
+// push the rest of the view off screen.
+func fitWidth(m model.Model, line string) string {
+	// tabs are expanded by the terminal, so their width can't be measured
+	line = strings.ReplaceAll(line, "\t", "    ")
+	if m.Width <= 0 {
+		return line
+	}
+	return ansi.Truncate(line, m.Width, "…")
+}
@@ -7,31 +7,43 @@ import (
 	"froggit/internal/tui/controls"
 	"froggit/internal/tui/model"
 	"froggit/internal/tui/styles"
+	"froggit/internal/utils"
 )
 
 // RenderLogGraphView renders the interactive git log graph.
 // It highlights the currently selected commit line.
 func RenderLogGraphView(m model.Model) string {
-	const viewport = 15 // number of lines to show at once
-
 	var sb strings.Builder
 
-	sb.WriteString(styles.HeaderStyle.Render("  Git Log Graph:") + "\n\n")
+	top := styles.HeaderStyle.Render("  Git Log Graph:") + "\n\n"
+	if m.LogSearchTitle != "" {
+		top = styles.HeaderStyle.Render("  Commits matching "+m.LogSearchTitle+":") + "\n\n"
+	}
+	top += renderFilterBar(m)
 
-	total := len(m.LogLines)
-	if total == 0 {
-		sb.WriteString(styles.HelpStyle.Render("No commits found\n"))
-	} else {
-		start := 0
-		if m.Cursor >= viewport/2 {
-			start = m.Cursor - viewport/2
-		}
-		if start+viewport > total {
-			start = max(0, total-viewport)
+	// rows are the visible log lines; the cursor is kept on one of them
+	rows := utils.VisibleIndices(&m)
+	selected := 0
+	for pos, i := range rows {
+		if i == m.Cursor {
+			selected = pos
 		}
-		end := min(total, start+viewport)
+	}
+
+	total := len(rows)
+	position := fmt.Sprintf("%d/%d", min(selected+1, total), total)
+	bottom := "\n" + styles.HelpStyle.Render(position) + renderSearchBar(m) +
+		"\n" + controls.NewLogGraphViewControls(m.LogSearchTitle != "").Render()
+	viewport := listHeight(m, top, bottom, 15)
 
-		for i := start; i < end; i++ {
+	sb.WriteString(top)
+	if len(m.LogLines) == 0 {
+		sb.WriteString(styles.HelpStyle.Render("No commits found\n"))
+	} else if total == 0 {
+		sb.WriteString(renderNoMatches(m))
+	} else {
+		start, end := listWindow(selected, total, viewport)
+		for _, i := range rows[start:end] {
 			line := m.LogLines[i]
 			cursor := "  "
 			style := styles.NormalStyle
@@ -41,25 +53,23 @@ func RenderLogGraphView(m model.Model) string {
 			}
 
 			parts := strings.SplitN(line, " ", 3)
-			if len(parts) >= 2 {
+			if m.SearchActive() {
+				line = highlightSearch(m, line, i)
+			} else if len(parts) >= 2 {
 				graph := styles.GraphSymbolStyle.Render(parts[0])
 				hash := styles.CommitHashStyle.Render(parts[1])
 				rest := ""
 				if len(parts) == 3 {
-					rest = parts[2]
+					rest = highlightMatches(m, parts[2])
 				}
 				line = fmt.Sprintf("%s %s %s", graph, hash, rest)
 			}
 
-			sb.WriteString(style.Render(cursor+" "+line) + "\n")
+			sb.WriteString(rowZone(i, fitWidth(m, style.Render(cursor+" "+line))) + "\n")
 		}
 	}
 
-	position := fmt.Sprintf("%d/%d", m.Cursor+1, total)
-	sb.WriteString("\n" + styles.HelpStyle.Render(position))
-
-	controlsWidget := controls.NewLogGraphViewControls()
-	sb.WriteString("\n" + controlsWidget.Render())
+	sb.WriteString(bottom)
 
 	return sb.String()
 }
@@ -0,0 +1,33 @@
+package view
+
+import (
+	"strings"
+
+	"froggit/internal/git"
+	"froggit/internal/tui/controls"
+	"froggit/internal/tui/model"
+	"froggit/internal/tui/styles"
+)
+
+// RenderLogSearchView lets the user pick how commits are searched and type the query.
+func RenderLogSearchView(m model.Model) string {
+	var s strings.Builder
+
+	s.WriteString(styles.HeaderStyle.Render("🔍 Search commits:") + "\n\n")
+
+	for _, kind := range git.LogSearchKinds {
+		line := "  " + kind.String() + "  " + kind.Description()
+		if kind == m.LogSearchKind {
+			s.WriteString(styles.SelectedStyle.Render("❯ "+kind.String()+"  "+kind.Description()) + "\n")
+		} else {
+			s.WriteString(styles.NormalStyle.Render(line) + "\n")
+		}
+	}
+
+	s.WriteString("\n" + styles.InputStyle.Render(m.LogSearchInput+"_") + "\n\n")
+
+	controlsWidget := controls.NewLogSearchViewControls()
+	s.WriteString(controlsWidget.Render())
+
+	return s.String()
+}
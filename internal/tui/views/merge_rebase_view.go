@@ -35,15 +35,12 @@ func RenderMergeView(m model.Model) string {
 		sb.WriteString("\n" + styles.HelpStyle.Render(m.Message) + "\n")
 	}
 	if len(m.LogLines) > 0 {
-		sb.WriteString(styles.WarningStyle.Render("\nConflicts detected in:") + "\n")
-		for _, file := range m.LogLines {
-			sb.WriteString(styles.HelpStyle.Render("- " + file + "\n"))
-		}
+		sb.WriteString(renderConflicts(m))
 		sb.WriteString(styles.HelpStyle.Render("[P] Proceed (merge --continue)  [X] Cancel (merge --abort)\n"))
 	}
 
 	hasSelection := m.DialogTarget != ""
-	controlsWidget := controls.NewMergeViewControls(hasSelection)
+	controlsWidget := controls.NewMergeViewControls(hasSelection, len(m.LogLines) > 0)
 	sb.WriteString("\n" + controlsWidget.Render())
 
 	return sb.String()
@@ -76,16 +73,28 @@ func RenderRebaseView(m model.Model) string {
 		sb.WriteString("\n" + styles.HelpStyle.Render(m.Message) + "\n")
 	}
 	if len(m.LogLines) > 0 {
-		sb.WriteString(styles.WarningStyle.Render("\nConflicts detected in:") + "\n")
-		for _, file := range m.LogLines {
-			sb.WriteString(styles.HelpStyle.Render("- " + file + "\n"))
-		}
+		sb.WriteString(renderConflicts(m))
 		sb.WriteString(styles.HelpStyle.Render("[P] Proceed (rebase --continue)  [X] Cancel (rebase --abort)\n"))
 	}
 
 	hasSelection := m.DialogTarget != ""
-	controlsWidget := controls.NewRebaseViewControls(hasSelection)
+	controlsWidget := controls.NewRebaseViewControls(hasSelection, len(m.LogLines) > 0)
 	sb.WriteString("\n" + controlsWidget.Render())
 
 	return sb.String()
 }
+
+// renderConflicts lists the conflicted files of a merge or rebase, with the
+// one [o] opens in the editor selected.
+func renderConflicts(m model.Model) string {
+	var sb strings.Builder
+	sb.WriteString(styles.WarningStyle.Render("\nConflicts detected in:") + "\n")
+	for i, file := range m.LogLines {
+		if i == m.ConflictCursor {
+			sb.WriteString(styles.SelectedStyle.Render("❯ "+file) + "\n")
+		} else {
+			sb.WriteString(styles.HelpStyle.Render("- "+file) + "\n")
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,41 @@
+package view
+
+import (
+	"fmt"
+	"strings"
+
+	"froggit/internal/git"
+	"froggit/internal/tui/controls"
+	"froggit/internal/tui/model"
+	"froggit/internal/tui/styles"
+)
+
+var pullStrategyDescriptions = map[string]string{
+	git.PullFFOnly:    "fast-forward only, fail if history diverged",
+	git.PullMerge:     "merge the upstream into the current branch",
+	git.PullRebase:    "replay local commits on top of the upstream",
+	git.PullAutostash: "rebase, stashing local changes around it",
+}
+
+func RenderPullView(m model.Model) string {
+	var s strings.Builder
+
+	s.WriteString(styles.HeaderStyle.Render("⬇ Pull strategy:") + "\n\n")
+	s.WriteString(styles.NormalStyle.Render(fmt.Sprintf("  ↑ %d local commits   ↓ %d remote commits", m.PullAhead, m.PullBehind)) + "\n\n")
+
+	for i, strategy := range git.PullStrategies {
+		cursor := "  "
+		style := styles.NormalStyle
+		if i == m.PullCursor {
+			cursor = "❯ "
+			style = styles.SelectedStyle
+		}
+		line := fmt.Sprintf("%s%-10s %s", cursor, strategy, pullStrategyDescriptions[strategy])
+		s.WriteString(style.Render(line) + "\n")
+	}
+
+	controlsWidget := controls.NewPullViewControls()
+	s.WriteString("\n" + controlsWidget.Render())
+
+	return s.String()
+}
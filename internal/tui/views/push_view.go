@@ -0,0 +1,59 @@
+package view
+
+import (
+	"fmt"
+	"strings"
+
+	"froggit/internal/tui/controls"
+	"froggit/internal/tui/model"
+	"froggit/internal/tui/styles"
+)
+
+func RenderPushView(m model.Model) string {
+	var s strings.Builder
+
+	s.WriteString(styles.HeaderStyle.Render("⬆ Push:") + "\n\n")
+
+	field := func(idx int, line string) {
+		cursor := "  "
+		style := styles.NormalStyle
+		if m.PushField == idx {
+			cursor = "❯ "
+			style = styles.SelectedStyle
+		}
+		s.WriteString(style.Render(cursor+line) + "\n")
+	}
+	check := func(on bool) string {
+		if on {
+			return "[✓]"
+		}
+		return "[ ]"
+	}
+
+	field(model.PushFieldRemote, fmt.Sprintf("Remote:  ◂ %s ▸", m.PushRemote))
+	field(model.PushFieldBranch, fmt.Sprintf("Branch:  %s_", m.PushBranch))
+	field(model.PushFieldForce, check(m.PushForce)+" force with lease")
+	if m.PushForce {
+		lease := fmt.Sprintf("      expects %s/%s at %s", m.PushRemote, m.PushBranch, shortSHA(m.PushRemoteSHA))
+		if m.PushRemoteSHA == "" {
+			lease = fmt.Sprintf("      expects %s/%s not to exist", m.PushRemote, m.PushBranch)
+		}
+		s.WriteString(styles.WarningStyle.Render(lease) + "\n")
+	}
+	field(model.PushFieldTags, check(m.PushTags)+" push tags")
+	field(model.PushFieldDryRun, check(m.PushDryRun)+" dry run")
+
+	s.WriteString("\n" + styles.HelpStyle.Render(fmt.Sprintf("  git push %s HEAD:%s", m.PushRemote, m.PushBranch)) + "\n")
+
+	controlsWidget := controls.NewPushViewControls()
+	s.WriteString(controlsWidget.Render())
+
+	return s.String()
+}
+
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
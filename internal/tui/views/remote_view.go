@@ -12,12 +12,17 @@ import (
 func RenderRemoteView(m model.Model) string {
 	var s strings.Builder
 
-	s.WriteString(styles.HeaderStyle.Render(" Remote repositories:") + "\n\n")
+	top := styles.HeaderStyle.Render(" Remote repositories:") + "\n\n"
+	controlsWidget := controls.NewRemoteViewControls()
+	bottom := "\n" + controlsWidget.Render()
+	viewport := listHeight(m, top, bottom, 15)
 
+	s.WriteString(top)
 	if len(m.Remotes) == 0 {
 		s.WriteString(styles.HelpStyle.Render("No remote repositories configured\n"))
 	} else {
-		for i, remote := range m.Remotes {
+		start, end := listWindow(m.Cursor, len(m.Remotes), viewport)
+		for i := start; i < end; i++ {
 			cursor := "  "
 			if m.Cursor == i {
 				cursor = " "
@@ -28,13 +33,12 @@ func RenderRemoteView(m model.Model) string {
 				style = styles.SelectedStyle
 			}
 
-			line := fmt.Sprintf("%s %s", cursor, remote)
+			line := fmt.Sprintf("%s %s", cursor, m.Remotes[i])
 			s.WriteString(style.Render(line) + "\n")
 		}
 	}
 
-	controlsWidget := controls.NewRemoteViewControls()
-	s.WriteString("\n" + controlsWidget.Render())
+	s.WriteString(bottom)
 
 	return s.String()
 }
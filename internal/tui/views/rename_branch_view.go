@@ -0,0 +1,35 @@
+package view
+
+import (
+	"strings"
+
+	"froggit/internal/tui/controls"
+	"froggit/internal/tui/model"
+	"froggit/internal/tui/styles"
+)
+
+func RenderRenameBranchView(m model.Model) string {
+	var s strings.Builder
+
+	s.WriteString(styles.HeaderStyle.Render("✏️ Rename branch "+m.RenameFrom+":") + "\n\n")
+	s.WriteString(styles.InputStyle.Render(m.RenameInput+"_") + "\n\n")
+
+	upstream := ""
+	for _, info := range m.BranchInfos {
+		if info.Name == m.RenameFrom && !info.Gone {
+			upstream = info.Upstream
+		}
+	}
+	if upstream != "" {
+		check := "[ ]"
+		if m.RenameOnRemote {
+			check = "[✓]"
+		}
+		s.WriteString(styles.NormalStyle.Render("  "+check+" also rename "+upstream+" on the remote") + "\n\n")
+	}
+
+	controlsWidget := controls.NewRenameBranchViewControls(upstream != "")
+	s.WriteString(controlsWidget.Render())
+
+	return s.String()
+}
@@ -7,33 +7,48 @@ import (
 	"froggit/internal/tui/controls"
 	"froggit/internal/tui/model"
 	"froggit/internal/tui/styles"
+	"froggit/internal/utils"
 )
 
 func RenderRepositoryListView(m model.Model) string {
-	const viewport = 12 // number of repos to show at once
 	var sb strings.Builder
 
-	total := len(m.Repositories)
+	rows := utils.VisibleIndices(&m)
+	selected := 0
+	for pos, i := range rows {
+		if i == m.SelectedRepoIndex {
+			selected = pos
+		}
+	}
+
+	total := len(rows)
 	headerText := "📚 GitHub Repositories"
 	if total > 0 {
-		headerText += fmt.Sprintf(" (%d/%d)", m.SelectedRepoIndex+1, total)
+		headerText += fmt.Sprintf(" (%d/%d)", selected+1, total)
 	}
-	sb.WriteString(styles.HeaderStyle.Render(headerText) + "\n\n")
+	top := styles.HeaderStyle.Render(headerText) + "\n\n" + renderFilterBar(m)
+	bottom := controls.NewRepositoryListControls().Render()
+	// one row is kept for the description of the selected repository
+	viewport := listHeight(m, top, bottom, 13) - 1
+
+	sb.WriteString(top)
 
-	if total == 0 {
+	if len(m.Repositories) == 0 {
 		sb.WriteString(styles.HelpStyle.Render("🔍 No repositories found.") + "\n")
 		sb.WriteString(styles.HelpStyle.Render("Make sure you have access to GitHub repositories.") + "\n\n")
+	} else if total == 0 {
+		sb.WriteString(renderNoMatches(m) + "\n")
 	} else {
 		start := 0
-		if m.SelectedRepoIndex >= viewport/2 {
-			start = m.SelectedRepoIndex - viewport/2
+		if selected >= viewport/2 {
+			start = selected - viewport/2
 		}
 		if start+viewport > total {
 			start = max(0, total-viewport)
 		}
 		end := min(total, start+viewport)
 
-		for i := start; i < end; i++ {
+		for _, i := range rows[start:end] {
 			repo := m.Repositories[i]
 			selected := m.SelectedRepoIndex == i
 
@@ -44,26 +59,25 @@ func RenderRepositoryListView(m model.Model) string {
 
 			icon := "📂"
 
-			repoName := styles.SuccessStyle.Render(repo.Owner.Login + "/" + repo.Name)
+			repoName := styles.SuccessStyle.Render(highlightMatches(m, repo.Owner.Login+"/"+repo.Name))
 			line := cursor + icon + " " + repoName
 
 
 			if selected {
-				sb.WriteString(styles.SelectedStyle.Render(line) + "\n")
+				sb.WriteString(rowZone(i, fitWidth(m, styles.SelectedStyle.Render(line))) + "\n")
 			} else {
-				sb.WriteString(styles.NormalStyle.Render(line) + "\n")
+				sb.WriteString(rowZone(i, fitWidth(m, styles.NormalStyle.Render(line))) + "\n")
 			}
 
 			if repo.Description != "" && selected {
 				desc := "  " + styles.HelpStyle.Render("  └─ " + repo.Description)
-				sb.WriteString(desc + "\n")
+				sb.WriteString(fitWidth(m, desc) + "\n")
 			}
 		}
 		sb.WriteString("\n")
 	}
 
-	controlsWidget := controls.NewRepositoryListControls()
-	sb.WriteString(controlsWidget.Render())
+	sb.WriteString(bottom)
 
 	return sb.String()
 }
\ No newline at end of file
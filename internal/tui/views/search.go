@@ -0,0 +1,63 @@
+package view
+
+import (
+	"fmt"
+	"strings"
+
+	"froggit/internal/tui/model"
+	"froggit/internal/tui/styles"
+	"froggit/internal/utils"
+)
+
+// renderSearchBar shows the search of the current view and the position of
+// the current match among all matching lines.
+func renderSearchBar(m model.Model) string {
+	if !m.SearchActive() && !(m.SearchTyping && m.SearchView == m.CurrentView) {
+		return ""
+	}
+
+	query := utils.SearchKey(m.CurrentView) + m.Search
+	if m.SearchTyping {
+		query = styles.InputStyle.Render(query) + styles.CursorStyle.Render("│")
+	} else {
+		query = styles.SubHeaderStyle.Render(query)
+	}
+
+	status := ""
+	if m.Search != "" {
+		total, rank := utils.SearchCount(&m)
+		if total == 0 {
+			status = styles.WarningStyle.Render("no matches")
+		} else {
+			status = styles.HelpStyle.Render(fmt.Sprintf("match %d/%d", rank, total))
+		}
+	}
+	return fmt.Sprintf("\n  %s %s", query, status)
+}
+
+// highlightSearch marks every match of the active search in text. Matches on
+// the current match line stand out from the others.
+func highlightSearch(m model.Model, text string, line int) string {
+	if !m.SearchActive() {
+		return text
+	}
+	re := utils.CompileSearch(m.Search)
+
+	style := styles.FilterMatchStyle
+	if line == m.SearchMatch {
+		style = styles.CursorStyle
+	}
+
+	var sb strings.Builder
+	last := 0
+	for _, loc := range re.FindAllStringIndex(text, -1) {
+		if loc[0] == loc[1] {
+			continue
+		}
+		sb.WriteString(text[last:loc[0]])
+		sb.WriteString(style.Render(text[loc[0]:loc[1]]))
+		last = loc[1]
+	}
+	sb.WriteString(text[last:])
+	return sb.String()
+}
@@ -0,0 +1,22 @@
+package view
+
+import (
+	"strings"
+
+	"froggit/internal/tui/controls"
+	"froggit/internal/tui/model"
+	"froggit/internal/tui/styles"
+)
+
+func RenderSetUpstreamView(m model.Model) string {
+	var s strings.Builder
+
+	s.WriteString(styles.HeaderStyle.Render("🔗 Set upstream of "+m.UpstreamBranch+":") + "\n\n")
+	s.WriteString(styles.InputStyle.Render(m.UpstreamInput+"_") + "\n")
+	s.WriteString(styles.HelpStyle.Render("  remote/branch, e.g. origin/main") + "\n\n")
+
+	controlsWidget := controls.NewSetUpstreamViewControls()
+	s.WriteString(controlsWidget.Render())
+
+	return s.String()
+}
@@ -2,64 +2,97 @@ package view
 
 import (
 	"fmt"
+	"froggit/internal/git"
 	"froggit/internal/tui/controls"
 	"froggit/internal/tui/model"
 	"froggit/internal/tui/styles"
+	"froggit/internal/utils"
 	"strings"
+	"time"
 )
 
 func RenderStashView(m model.Model) string {
 	var sb strings.Builder
 
-	sb.WriteString(styles.HeaderStyle.Render("Git Stash Manager") + "\n\n")
-
+	top := styles.HeaderStyle.Render("Git Stash Manager") + "\n\n"
 	if hasUnstagedChanges(m) {
-		sb.WriteString(styles.WarningStyle.Render("⚠ You have unstaged changes that can be stashed") + "\n\n")
+		top += styles.WarningStyle.Render("⚠ You have unstaged changes that can be stashed") + "\n\n"
 	}
 
+	// rows are the lines of the list, a stash taking one to three; selected
+	// is the first one of the stash under the cursor
+	var rows []string
+	selected := 0
 	if len(m.Stashes) == 0 {
-		sb.WriteString(styles.HelpStyle.Render("No stashes found. Create one with [S] Save stash") + "\n\n")
+		top += styles.HelpStyle.Render("No stashes found. Create one with [S] Save stash") + "\n\n"
 	} else {
-		sb.WriteString(styles.SubHeaderStyle.Render("Existing Stashes:") + "\n")
+		top += styles.SubHeaderStyle.Render("Existing Stashes:") + "\n"
+		top += renderFilterBar(m)
+		if m.FilterActive() && len(utils.VisibleIndices(&m)) == 0 {
+			top += renderNoMatches(m)
+		}
 
+		now := time.Now()
 		for i, stash := range m.Stashes {
+			if !utils.IsVisible(&m, i) {
+				continue
+			}
 			cursor := "  "
 			if i == m.Cursor {
 				cursor = "❯ "
+				selected = len(rows)
 			}
 
-			stashInfo := parseStashInfo(stash)
+			stashInfo := highlightMatches(m, parseStashInfo(stash))
 			line := fmt.Sprintf("%s%s", cursor, stashInfo)
 
 			if i == m.Cursor {
-				sb.WriteString(styles.SelectedStyle.Render(line) + "\n")
+				line = rowZone(i, styles.SelectedStyle.Render(line))
 			} else {
-				sb.WriteString(styles.NormalStyle.Render(line) + "\n")
+				line = rowZone(i, styles.NormalStyle.Render(line))
 			}
+			info, ok := m.StashInfoOf(git.GetStashRef(stash))
+			if !ok {
+				rows = append(rows, line)
+				continue
+			}
+			rows = append(rows, line+styles.HelpStyle.Render("  "+utils.RelativeTime(info.Date, now)))
+			rows = append(rows, styles.HelpStyle.Render("    "+stashFiles(info)))
 		}
-		sb.WriteString("\n")
+	}
+
+	var bottom strings.Builder
+	if len(m.Stashes) > 0 {
+		bottom.WriteString("\n")
 	}
 
 	if m.Message != "" {
 		switch m.MessageType {
 		case "success":
-			sb.WriteString(styles.SuccessStyle.Render(m.Message) + "\n")
+			bottom.WriteString(styles.SuccessStyle.Render(m.Message) + "\n")
 		case "error":
-			sb.WriteString(styles.ErrorStyle.Render(m.Message) + "\n")
+			bottom.WriteString(styles.ErrorStyle.Render(m.Message) + "\n")
 		case "warning":
-			sb.WriteString(styles.WarningStyle.Render(m.Message) + "\n")
+			bottom.WriteString(styles.WarningStyle.Render(m.Message) + "\n")
 		default:
-			sb.WriteString(styles.HelpStyle.Render(m.Message) + "\n")
+			bottom.WriteString(styles.HelpStyle.Render(m.Message) + "\n")
 		}
-		sb.WriteString("\n")
+		bottom.WriteString("\n")
 	}
 
 	if m.IsStashing {
-		sb.WriteString(styles.HelpStyle.Render(fmt.Sprintf("%s Processing stash operation...", m.SpinnerFrames[m.SpinnerIndex])) + "\n\n")
+		bottom.WriteString(styles.HelpStyle.Render(fmt.Sprintf("%s Processing stash operation...", m.SpinnerFrames[m.SpinnerIndex])) + "\n\n")
 	}
 
 	controlsWidget := controls.NewStashViewControls(hasUnstagedChanges(m), len(m.Stashes) > 0)
-	sb.WriteString(controlsWidget.Render())
+	bottom.WriteString(controlsWidget.Render())
+
+	sb.WriteString(top)
+	first, last := listWindow(selected, len(rows), listHeight(m, top, bottom.String(), 15))
+	for _, row := range rows[first:last] {
+		sb.WriteString(row + "\n")
+	}
+	sb.WriteString(bottom.String())
 
 	return sb.String()
 }
@@ -75,10 +108,47 @@ func RenderStashMessageView(m model.Model) string {
 	if len(inputDisplay) == 0 {
 		inputDisplay = "Work in progress..."
 	}
-	sb.WriteString(styles.InputStyle.Render(inputDisplay) + styles.CursorStyle.Render("│") + "\n\n")
+	cursor := ""
+	if m.StashField == model.StashFieldMessage {
+		cursor = styles.CursorStyle.Render("│")
+	}
+	sb.WriteString(styles.InputStyle.Render(inputDisplay) + cursor + "\n\n")
 
-	sb.WriteString(styles.HelpStyle.Render("📝 This will stash all your current changes") + "\n")
-	sb.WriteString(styles.HelpStyle.Render("   including both staged and unstaged files") + "\n\n")
+	field := func(idx int, on bool, label string) {
+		check := "[ ]"
+		if on {
+			check = "[✓]"
+		}
+		cursor, style := "  ", styles.NormalStyle
+		if m.StashField == idx {
+			cursor, style = "❯ ", styles.SelectedStyle
+		}
+		sb.WriteString(style.Render(cursor+check+" "+label) + "\n")
+	}
+	if len(m.StashPaths) > 0 {
+		cursor := "  "
+		if m.StashField == model.StashFieldStaged {
+			cursor = "❯ "
+		}
+		sb.WriteString(styles.HelpStyle.Render(cursor+"[-] staged changes only, not for marked files") + "\n")
+	} else {
+		field(model.StashFieldStaged, m.StashStaged, "staged changes only")
+	}
+	field(model.StashFieldKeepIndex, m.StashKeepIndex, "keep the staged changes in the worktree")
+	field(model.StashFieldUntracked, m.StashUntracked, "include untracked files")
+	sb.WriteString("\n")
+
+	what := "all your current changes"
+	if m.StashStaged {
+		what = "your staged changes"
+	}
+	if len(m.StashPaths) > 0 {
+		what += fmt.Sprintf(" in the %d marked files", len(m.StashPaths))
+	}
+	if m.StashUntracked {
+		what += " and untracked files"
+	}
+	sb.WriteString(styles.HelpStyle.Render("📝 This will stash "+what) + "\n\n")
 
 	if m.Message != "" {
 		switch m.MessageType {
@@ -95,11 +165,59 @@ func RenderStashMessageView(m model.Model) string {
 	return sb.String()
 }
 
+// RenderStashInputView asks for the branch a stash is turned into, or the
+// new message of a stash.
+func RenderStashInputView(m model.Model) string {
+	var sb strings.Builder
+
+	if m.StashAction == "branch" {
+		sb.WriteString(styles.HeaderStyle.Render("🌿 New branch from "+m.StashRef+":") + "\n\n")
+	} else {
+		sb.WriteString(styles.HeaderStyle.Render("✏️ Rename "+m.StashRef+":") + "\n\n")
+	}
+	sb.WriteString(styles.InputStyle.Render(m.StashInput+"_") + "\n\n")
+
+	if m.StashAction == "branch" {
+		sb.WriteString(styles.HelpStyle.Render("📝 The branch starts at the commit the stash was made on,") + "\n")
+		sb.WriteString(styles.HelpStyle.Render("   the stash is applied there and dropped") + "\n\n")
+	} else {
+		sb.WriteString(styles.HelpStyle.Render("📝 The renamed stash moves to the top as stash@{0}") + "\n\n")
+	}
+
+	controlsWidget := controls.NewStashInputViewControls(m.StashAction)
+	sb.WriteString(controlsWidget.Render())
+
+	return sb.String()
+}
+
 // hasUnstagedChanges checks if there are any changes that can be stashed
 func hasUnstagedChanges(m model.Model) bool {
 	return len(m.Files) > 0
 }
 
+// stashFiles summarizes the files a stash changes, e.g.
+// "3 files: main.go, go.mod, +1 more".
+func stashFiles(info git.StashInfo) string {
+	const shown = 3
+	names := info.Files
+	more := ""
+	if len(names) > shown {
+		more = fmt.Sprintf(", +%d more", len(names)-shown)
+		names = names[:shown]
+	}
+	summary := fmt.Sprintf("%d files: %s%s", len(info.Files), strings.Join(names, ", "), more)
+	if len(info.Files) == 1 {
+		summary = "1 file: " + info.Files[0]
+	}
+	if info.Untracked {
+		if len(info.Files) == 0 {
+			return "untracked files"
+		}
+		summary += ", and untracked files"
+	}
+	return summary
+}
+
 // parseStashInfo formats stash information for display
 func parseStashInfo(stashLine string) string {
 	// Input: "stash@{0}: WIP on main: 1234567 commit message"
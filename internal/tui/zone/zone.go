@@ -0,0 +1,133 @@
+// Package zone marks regions of the rendered screen, such as list rows and
+// footer controls, so mouse events can be mapped back to what they hit.
+//
+// Views wrap text with Mark while rendering; Scan then strips the markers
+// from the final frame and records where each marked region ended up.
+package zone
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// markerBase offsets marker numbers so they can't clash with other CSI "z" sequences.
+const markerBase = 7000
+
+// Zone is a region of the screen. Single-line zones span the columns
+// [X0, X1); zones spanning several lines cover whole rows Y0 through Y1.
+type Zone struct {
+	ID             string
+	X0, Y0, X1, Y1 int
+}
+
+// Contains reports whether the cell at x, y lies inside the zone.
+func (z Zone) Contains(x, y int) bool {
+	if y < z.Y0 || y > z.Y1 {
+		return false
+	}
+	if z.Y0 == z.Y1 {
+		return x >= z.X0 && x < z.X1
+	}
+	return true
+}
+
+var (
+	mu      sync.Mutex
+	pending []string // ids marked in the frame being rendered
+	zones   []Zone   // zones of the last scanned frame
+)
+
+// Mark wraps s in invisible markers identifying it as zone id.
+func Mark(id, s string) string {
+	mu.Lock()
+	n := markerBase + len(pending)
+	pending = append(pending, id)
+	mu.Unlock()
+
+	marker := fmt.Sprintf("\x1b[%dz", n)
+	return marker + s + marker
+}
+
+// Scan removes the markers from a rendered frame and records the position of
+// every marked zone for Find.
+func Scan(s string) string {
+	mu.Lock()
+	defer mu.Unlock()
+
+	ids := pending
+	pending = nil
+	zones = zones[:0]
+
+	var out strings.Builder
+	open := make(map[int]Zone)
+	x, y := 0, 0
+	state := byte(0)
+	for len(s) > 0 {
+		seq, width, n, newState := ansi.DecodeSequence(s, state, nil)
+		state = newState
+		s = s[n:]
+
+		if seq == "\n" {
+			x = 0
+			y++
+			out.WriteString(seq)
+			continue
+		}
+
+		if num, ok := markerNumber(seq); ok {
+			// markers of another frame are dropped along with their zones
+			if num-markerBase >= len(ids) {
+				continue
+			}
+			if z, started := open[num]; started {
+				z.X1, z.Y1 = x, y
+				zones = append(zones, z)
+				delete(open, num)
+			} else {
+				open[num] = Zone{ID: ids[num-markerBase], X0: x, Y0: y}
+			}
+			continue
+		}
+
+		x += width
+		out.WriteString(seq)
+	}
+	return out.String()
+}
+
+// markerNumber parses the number of a "\x1b[<n>z" marker.
+func markerNumber(seq string) (int, bool) {
+	if !strings.HasPrefix(seq, "\x1b[") || !strings.HasSuffix(seq, "z") {
+		return 0, false
+	}
+	num, err := strconv.Atoi(seq[2 : len(seq)-1])
+	if err != nil || num < markerBase {
+		return 0, false
+	}
+	return num, true
+}
+
+// Find returns the id of the innermost zone under x, y whose id starts with
+// prefix.
+func Find(x, y int, prefix string) (string, bool) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	best := Zone{}
+	found := false
+	for _, z := range zones {
+		if !strings.HasPrefix(z.ID, prefix) || !z.Contains(x, y) {
+			continue
+		}
+		// later zones were closed first when nested, so prefer the smaller one
+		if !found || z.Y1-z.Y0 < best.Y1-best.Y0 || (z.Y0 == z.Y1 && z.X1-z.X0 < best.X1-best.X0) {
+			best = z
+			found = true
+		}
+	}
+	return best.ID, found
+}
@@ -0,0 +1,56 @@
+package zone
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestScan(t *testing.T) {
+	frame := "header\n  " + Mark("row:0", "first") + "\n" +
+		lipgloss.NewStyle().Bold(true).Render(Mark("row:1", "second")) + " " + Mark("control:q", "q quit")
+
+	out := Scan(frame)
+	want := "header\n  first\n" + lipgloss.NewStyle().Bold(true).Render("second") + " q quit"
+	if out != want {
+		t.Fatalf("Scan left markers behind: %q", out)
+	}
+
+	cases := []struct {
+		x, y   int
+		prefix string
+		want   string
+		ok     bool
+	}{
+		{2, 1, "row:", "row:0", true},
+		{6, 1, "row:", "row:0", true},
+		{7, 1, "row:", "", false},
+		{0, 2, "row:", "row:1", true},
+		{8, 2, "control:", "control:q", true},
+		{0, 0, "", "", false},
+	}
+	for _, c := range cases {
+		got, ok := Find(c.x, c.y, c.prefix)
+		if got != c.want || ok != c.ok {
+			t.Fatalf("Find(%d, %d, %q) = %q, %v; want %q, %v", c.x, c.y, c.prefix, got, ok, c.want, c.ok)
+		}
+	}
+}
+
+func TestScanStripsMarkersOfAnotherFrame(t *testing.T) {
+	frame := "  " + Mark("row:0", "first") + "\n" + Mark("control:q", "q quit")
+	Scan(frame)
+
+	// scanning the same markers again finds no ids for them
+	out := Scan(frame)
+	if want := "  first\nq quit"; out != want {
+		t.Fatalf("Scan left markers behind: %q", out)
+	}
+	if strings.Contains(out, "\x1b[") {
+		t.Fatalf("Scan left escape sequences behind: %q", out)
+	}
+	if id, ok := Find(2, 0, "row:"); ok {
+		t.Fatalf("Find found %q from another frame", id)
+	}
+}
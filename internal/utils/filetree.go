@@ -0,0 +1,192 @@
+package utils
+
+import (
+	"path"
+	"sort"
+	"strings"
+
+	"froggit/internal/tui/model"
+)
+
+// TreeRow is a row of FileView in tree mode: a directory or a file.
+type TreeRow struct {
+	Dir       string // path of a directory row, "" for a file row
+	File      int    // index in m.Files of a file row, -1 for a directory
+	Label     string // file name, or the directory names the row stands for
+	Depth     int
+	Files     int // files below a directory
+	Staged    int // how many of them are staged
+	Collapsed bool
+}
+
+// treeNode is a directory while the tree is built.
+type treeNode struct {
+	path   string
+	dirs   map[string]*treeNode
+	files  []int
+	count  int
+	staged int
+}
+
+func newTreeNode(path string) *treeNode {
+	return &treeNode{path: path, dirs: make(map[string]*treeNode)}
+}
+
+// FileTree lays out the visible files of m as a directory tree, directories
+// before files and both sorted by name. A directory holding nothing but one
+// subdirectory shares its row, as in "internal/tui". The contents of
+// collapsed directories are left out, unless a filter is active.
+func FileTree(m *model.Model) []TreeRow {
+	root := newTreeNode("")
+	for _, idx := range FileViewOrder(m) {
+		file := m.Files[idx]
+		// untracked directories are listed as "dir/" and stay leaves
+		dirs := strings.Split(strings.TrimSuffix(file.Name, "/"), "/")
+		dirs = dirs[:len(dirs)-1]
+
+		node := root
+		node.add(file.Staged)
+		for _, name := range dirs {
+			child, ok := node.dirs[name]
+			if !ok {
+				child = newTreeNode(path.Join(node.path, name))
+				node.dirs[name] = child
+			}
+			node = child
+			node.add(file.Staged)
+		}
+		node.files = append(node.files, idx)
+	}
+
+	var rows []TreeRow
+	root.appendRows(m, &rows, 0)
+	return rows
+}
+
+func (n *treeNode) add(staged bool) {
+	n.count++
+	if staged {
+		n.staged++
+	}
+}
+
+func (n *treeNode) appendRows(m *model.Model, rows *[]TreeRow, depth int) {
+	names := make([]string, 0, len(n.dirs))
+	for name := range n.dirs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		dir := n.dirs[name]
+		label := name
+		for len(dir.files) == 0 && len(dir.dirs) == 1 {
+			for sub, only := range dir.dirs {
+				label += "/" + sub
+				dir = only
+			}
+		}
+		collapsed := m.CollapsedDirs[dir.path] && !m.FilterActive()
+		*rows = append(*rows, TreeRow{
+			Dir:       dir.path,
+			File:      -1,
+			Label:     label,
+			Depth:     depth,
+			Files:     dir.count,
+			Staged:    dir.staged,
+			Collapsed: collapsed,
+		})
+		if !collapsed {
+			dir.appendRows(m, rows, depth+1)
+		}
+	}
+
+	files := append([]int(nil), n.files...)
+	sort.Slice(files, func(i, j int) bool {
+		return m.Files[files[i]].Name < m.Files[files[j]].Name
+	})
+	for _, idx := range files {
+		name := m.Files[idx].Name
+		label := path.Base(name)
+		if strings.HasSuffix(name, "/") {
+			label += "/"
+		}
+		*rows = append(*rows, TreeRow{File: idx, Label: label, Depth: depth})
+	}
+}
+
+// TreeRowOf returns the row of the selection in rows: the selected directory,
+// or the file under the cursor. A file hidden in a collapsed directory is
+// found at the row of that directory. It returns -1 when neither is shown.
+func TreeRowOf(m *model.Model, rows []TreeRow) int {
+	target := m.TreeDir
+	if target == "" {
+		if m.Cursor < 0 || m.Cursor >= len(m.Files) {
+			return -1
+		}
+		target = m.Files[m.Cursor].Name
+	}
+
+	found := -1
+	for i, row := range rows {
+		switch {
+		case row.File >= 0 && m.TreeDir == "" && row.File == m.Cursor:
+			return i
+		case row.Dir == m.TreeDir && m.TreeDir != "":
+			return i
+		case row.Collapsed && InDir(target, row.Dir):
+			found = i
+		}
+	}
+	return found
+}
+
+// SelectTreeRow puts the selection on row.
+func SelectTreeRow(m *model.Model, row TreeRow) {
+	if row.File >= 0 {
+		m.Cursor = row.File
+		m.TreeDir = ""
+	} else {
+		m.TreeDir = row.Dir
+	}
+}
+
+// SelectedDir returns the directory selected in tree mode, if any.
+func SelectedDir(m *model.Model) (string, bool) {
+	if !m.FileTree || m.TreeDir == "" || !m.HasFilesIn(m.TreeDir) {
+		return "", false
+	}
+	return m.TreeDir, true
+}
+
+// InDir reports whether path is below directory dir.
+func InDir(path, dir string) bool {
+	return strings.HasPrefix(path, dir+"/")
+}
+
+// FilesIn returns the names of the changed files inside directory dir,
+// whether they pass the filter or not.
+func FilesIn(m *model.Model, dir string) []string {
+	var names []string
+	for _, file := range m.Files {
+		if InDir(file.Name, dir) {
+			names = append(names, file.Name)
+		}
+	}
+	return names
+}
+
+// moveTreeCursor moves the selection of the tree by delta rows.
+func moveTreeCursor(m *model.Model, delta int) {
+	rows := FileTree(m)
+	if len(rows) == 0 {
+		return
+	}
+	pos := TreeRowOf(m, rows)
+	if pos == -1 {
+		pos = 0
+	} else {
+		pos = max(0, min(len(rows)-1, pos+delta))
+	}
+	SelectTreeRow(m, rows[pos])
+}
@@ -0,0 +1,245 @@
+package utils
+
+import (
+	"froggit/internal/tui/model"
+)
+
+// IsFilterable reports whether view supports the "/" filter mode.
+func IsFilterable(view model.View) bool {
+	switch view {
+	case model.FileView, model.BranchView, model.RepositoryListView, model.StashView, model.LogGraphView:
+		return true
+	}
+	return false
+}
+
+// ListItems returns the text the filter matches against for each item of the
+// current view, indexed like the underlying slice.
+func ListItems(m *model.Model) []string {
+	var items []string
+	switch m.CurrentView {
+	case model.FileView:
+		for _, f := range m.Files {
+			items = append(items, f.Name)
+		}
+	case model.BranchView:
+		items = append(items, m.Branches...)
+		for _, b := range m.RemoteBranches {
+			items = append(items, b.Ref())
+		}
+	case model.RepositoryListView:
+		for _, r := range m.Repositories {
+			items = append(items, r.Owner.Login+"/"+r.Name)
+		}
+	case model.StashView:
+		items = m.Stashes
+	case model.LogGraphView:
+		items = m.LogLines
+	}
+	return items
+}
+
+// IsVisible reports whether item i of the current view passes the filter.
+func IsVisible(m *model.Model, i int) bool {
+	if !m.FilterActive() {
+		return true
+	}
+	items := ListItems(m)
+	if i < 0 || i >= len(items) {
+		return false
+	}
+	_, ok := FuzzyMatch(m.Filter, items[i])
+	return ok
+}
+
+// VisibleIndices returns the indices of the items shown in the current view,
+// in display order.
+func VisibleIndices(m *model.Model) []int {
+	if m.CurrentView == model.FileView {
+		return FileViewOrder(m)
+	}
+	var visible []int
+	for i, item := range ListItems(m) {
+		if !m.FilterActive() {
+			visible = append(visible, i)
+			continue
+		}
+		if _, ok := FuzzyMatch(m.Filter, item); ok {
+			visible = append(visible, i)
+		}
+	}
+	return visible
+}
+
+// FileViewOrder returns the visible file indices in the order FileView shows
+// them: staged files first, then unstaged ones.
+func FileViewOrder(m *model.Model) []int {
+	var staged, unstaged []int
+	for i, f := range m.Files {
+		if m.FilterActive() {
+			if _, ok := FuzzyMatch(m.Filter, f.Name); !ok {
+				continue
+			}
+		}
+		if f.Staged {
+			staged = append(staged, i)
+		} else {
+			unstaged = append(unstaged, i)
+		}
+	}
+	return append(staged, unstaged...)
+}
+
+// cursorOf returns the cursor field used by the current view.
+func cursorOf(m *model.Model) *int {
+	if m.CurrentView == model.RepositoryListView {
+		return &m.SelectedRepoIndex
+	}
+	return &m.Cursor
+}
+
+// SelectItem puts the cursor of the current view on item idx, reporting
+// false when the item does not exist or is hidden by the filter.
+func SelectItem(m *model.Model, idx int) bool {
+	if idx < 0 || idx >= len(ListItems(m)) || !IsVisible(m, idx) {
+		return false
+	}
+	*cursorOf(m) = idx
+	if m.CurrentView == model.FileView {
+		m.TreeDir = ""
+		EnsureFileVisible(m)
+	}
+	return true
+}
+
+// MoveCursor moves the cursor of the current view by delta visible items,
+// skipping items hidden by the filter.
+func MoveCursor(m *model.Model, delta int) {
+	if m.CurrentView == model.FileView && m.FileTree {
+		moveTreeCursor(m, delta)
+		EnsureFileVisible(m)
+		return
+	}
+
+	visible := VisibleIndices(m)
+	if len(visible) == 0 {
+		return
+	}
+	cursor := cursorOf(m)
+
+	pos := -1
+	for i, idx := range visible {
+		if idx == *cursor {
+			pos = i
+			break
+		}
+	}
+	if pos == -1 {
+		*cursor = visible[0]
+	} else {
+		pos = max(0, min(len(visible)-1, pos+delta))
+		*cursor = visible[pos]
+	}
+
+	if m.CurrentView == model.FileView {
+		EnsureFileVisible(m)
+	}
+}
+
+// FileRows lays out the rows of FileView: the staged files under a header,
+// then the unstaged ones under another. Rows can be looked up without
+// walking the list, so only the visible ones need rendering.
+type FileRows struct {
+	Order  []int // visible files, staged first
+	Staged int   // how many of Order are staged
+}
+
+// NewFileRows lays out the files of m that pass the filter.
+func NewFileRows(m *model.Model) FileRows {
+	r := FileRows{Order: FileViewOrder(m)}
+	for _, i := range r.Order {
+		if m.Files[i].Staged {
+			r.Staged++
+		}
+	}
+	return r
+}
+
+// Len returns the number of rows, headers included.
+func (r FileRows) Len() int {
+	n := len(r.Order)
+	if r.Staged > 0 {
+		n++
+	}
+	if r.Staged < len(r.Order) {
+		n++
+	}
+	return n
+}
+
+// At returns the file shown at row, or -1 and the title of the group header
+// found there.
+func (r FileRows) At(row int) (int, string) {
+	if r.Staged > 0 {
+		if row == 0 {
+			return -1, "Staged"
+		}
+		row--
+		if row < r.Staged {
+			return r.Order[row], ""
+		}
+		row -= r.Staged
+	}
+	if row == 0 {
+		return -1, "Unstaged"
+	}
+	return r.Order[r.Staged+row-1], ""
+}
+
+// Row returns the row of file idx, or 0 when it isn't shown.
+func (r FileRows) Row(idx int) int {
+	for pos, i := range r.Order {
+		if i != idx {
+			continue
+		}
+		row := pos
+		if r.Staged > 0 {
+			row++ // staged header
+		}
+		if pos >= r.Staged {
+			row++ // unstaged header
+		}
+		return row
+	}
+	return 0
+}
+
+// FileRow returns the row of file idx in FileView, counting group headers.
+func FileRow(m *model.Model, idx int) int {
+	return NewFileRows(m).Row(idx)
+}
+
+// EnsureFileVisible scrolls FileView so the row of the cursor is inside the viewport.
+func EnsureFileVisible(m *model.Model) {
+	height := m.FileViewHeight
+	if height <= 0 {
+		return
+	}
+	if m.FileTree {
+		row := max(0, TreeRowOf(m, FileTree(m)))
+		m.FileViewOffset = max(min(m.FileViewOffset, row), row-height+1)
+		return
+	}
+
+	row := FileRow(m, m.Cursor)
+	if row < m.FileViewOffset {
+		m.FileViewOffset = row
+		// keep the group header above the first file visible
+		if m.FileViewOffset == 1 {
+			m.FileViewOffset = 0
+		}
+	}
+	if row >= m.FileViewOffset+height {
+		m.FileViewOffset = row - height + 1
+	}
+}
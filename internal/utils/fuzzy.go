@@ -0,0 +1,32 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+)
+
+// FuzzyMatch reports whether all runes of pattern appear in text in order,
+// ignoring case, and returns the rune positions in text that matched.
+// Consecutive matches are preferred by matching greedily from the left.
+func FuzzyMatch(pattern, text string) ([]int, bool) {
+	if pattern == "" {
+		return nil, true
+	}
+	needle := []rune(strings.ToLower(pattern))
+	positions := make([]int, 0, len(needle))
+
+	j := 0
+	for i, r := range []rune(text) {
+		if j == len(needle) {
+			break
+		}
+		if unicode.ToLower(r) == needle[j] {
+			positions = append(positions, i)
+			j++
+		}
+	}
+	if j < len(needle) {
+		return nil, false
+	}
+	return positions, true
+}
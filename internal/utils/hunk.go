@@ -0,0 +1,72 @@
+package utils
+
+import (
+	"bufio"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// hunkHeaderRe reads the first old and new line numbers of a hunk header.
+var hunkHeaderRe = regexp.MustCompile(`^@@ -(\d+)(?:,\d+)? \+(\d+)`)
+
+// ParseHunkHeader returns the line a hunk starts at in the old and the new
+// version of the file.
+func ParseHunkHeader(line string) (oldStart, newStart int, ok bool) {
+	match := hunkHeaderRe.FindStringSubmatch(line)
+	if match == nil {
+		return 0, 0, false
+	}
+	oldStart, _ = strconv.Atoi(match[1])
+	newStart, _ = strconv.Atoi(match[2])
+	return oldStart, newStart, true
+}
+
+// DiffLineNumber returns the line in the new version of the file that line
+// index of a unified diff corresponds to. Removed lines map to where they
+// were, and lines before the first hunk to the start of the next hunk. It
+// returns 0 when the diff has no hunk to go by.
+func DiffLineNumber(lines []string, index int) int {
+	line := 0
+	for i, raw := range lines {
+		l := ansi.Strip(raw)
+		if _, start, ok := ParseHunkHeader(l); ok {
+			line = start
+			if i >= index {
+				return line
+			}
+			continue
+		}
+		if strings.HasPrefix(l, "diff ") {
+			line = 0
+		}
+		if i >= index && line > 0 {
+			return line
+		}
+		if line > 0 && !strings.HasPrefix(l, "-") {
+			line++
+		}
+	}
+	return 0
+}
+
+// ConflictLine returns the line of the first conflict marker in a file, or 0
+// when it has none.
+func ConflictLine(path string) int {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		if strings.HasPrefix(scanner.Text(), "<<<<<<<") {
+			return n
+		}
+	}
+	return 0
+}
@@ -0,0 +1,138 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"froggit/internal/tui/model"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// IsSearchable reports whether view supports search with n/N.
+func IsSearchable(view model.View) bool {
+	return view == model.DiffView || view == model.LogGraphView
+}
+
+// SearchKey returns the key starting a search in view: "/" unless the view
+// already uses it for its filter.
+func SearchKey(view model.View) string {
+	if IsFilterable(view) {
+		return "f"
+	}
+	return "/"
+}
+
+// CompileSearch turns a search query into a regexp. Queries without upper
+// case letters match case-insensitively, and a pattern that is not a valid
+// regexp (often one still being typed) is matched literally.
+func CompileSearch(query string) *regexp.Regexp {
+	prefix := ""
+	if !strings.ContainsFunc(query, unicode.IsUpper) {
+		prefix = "(?i)"
+	}
+	re, err := regexp.Compile(prefix + query)
+	if err != nil {
+		re = regexp.MustCompile(prefix + regexp.QuoteMeta(query))
+	}
+	return re
+}
+
+// SearchLines returns the lines searched in the current view.
+func SearchLines(m *model.Model) []string {
+	if m.CurrentView == model.DiffView && m.DiffExternal() {
+		// diffs from external renderers carry their colors
+		lines := make([]string, len(m.DiffLines))
+		for i, line := range m.DiffLines {
+			lines[i] = ansi.Strip(line)
+		}
+		return lines
+	}
+	if m.CurrentView == model.DiffView {
+		return m.DiffLines
+	}
+	return m.LogLines
+}
+
+// searchPosition returns the field a search match moves: the scroll offset of
+// DiffView or the cursor of LogGraphView.
+func searchPosition(m *model.Model) *int {
+	if m.CurrentView == model.DiffView {
+		return &m.DiffViewOffset
+	}
+	return &m.Cursor
+}
+
+// SearchFrom returns where a new search starts in the current view.
+func SearchFrom(m *model.Model) int {
+	return *searchPosition(m)
+}
+
+// FindMatch returns the first line matching re starting at from and moving in
+// dir (1 or -1), wrapping around the ends, or -1 if no line matches.
+func FindMatch(lines []string, re *regexp.Regexp, from, dir int) int {
+	return findMatch(len(lines), func(i int) bool { return re.MatchString(lines[i]) }, from, dir)
+}
+
+func findMatch(n int, match func(i int) bool, from, dir int) int {
+	if n == 0 {
+		return -1
+	}
+	from = ((from % n) + n) % n
+	for k := 0; k < n; k++ {
+		i := ((from+k*dir)%n + n) % n
+		if match(i) {
+			return i
+		}
+	}
+	return -1
+}
+
+// CountMatches returns how many lines match re and the 1-based rank of line
+// among them (0 if line does not match).
+func CountMatches(lines []string, re *regexp.Regexp, line int) (total, rank int) {
+	return countMatches(len(lines), func(i int) bool { return re.MatchString(lines[i]) }, line)
+}
+
+func countMatches(n int, match func(i int) bool, line int) (total, rank int) {
+	for i := 0; i < n; i++ {
+		if match(i) {
+			total++
+			if i == line {
+				rank = total
+			}
+		}
+	}
+	return total, rank
+}
+
+// searchMatcher reports whether line i of the current view matches m.Search.
+// Lines hidden by the filter of the log never match.
+func searchMatcher(m *model.Model) (int, func(i int) bool) {
+	lines := SearchLines(m)
+	re := CompileSearch(m.Search)
+	return len(lines), func(i int) bool {
+		return re.MatchString(lines[i]) && IsVisible(m, i)
+	}
+}
+
+// SearchCount returns how many lines of the current view match m.Search and
+// the 1-based rank of the current match among them.
+func SearchCount(m *model.Model) (total, rank int) {
+	n, match := searchMatcher(m)
+	return countMatches(n, match, m.SearchMatch)
+}
+
+// JumpToMatch moves the current view to the next match of m.Search starting at
+// from in direction dir and reports whether one was found.
+func JumpToMatch(m *model.Model, from, dir int) bool {
+	n, match := searchMatcher(m)
+	found := findMatch(n, match, from, dir)
+	m.SearchMatch = found
+	if found < 0 {
+		return false
+	}
+	*searchPosition(m) = found
+	return true
+}
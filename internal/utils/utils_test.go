@@ -1,8 +1,11 @@
 package utils
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
+	"froggit/internal/git"
 	"froggit/internal/tui/model"
 )
 
@@ -63,3 +66,205 @@ func TestValidateCursor_FileBranchRemoteViews(t *testing.T) {
 		t.Fatalf("expected cursor 0 for negative cursor, got %d", m.Cursor)
 	}
 }
+
+func TestRelativeTime(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	cases := []struct {
+		t    time.Time
+		want string
+	}{
+		{now.Add(-30 * time.Second), "just now"},
+		{now.Add(-time.Minute), "1 minute ago"},
+		{now.Add(-5 * time.Hour), "5 hours ago"},
+		{now.Add(-10 * 24 * time.Hour), "1 week ago"},
+		{now.Add(-400 * 24 * time.Hour), "1 year ago"},
+		{time.Time{}, ""},
+	}
+	for _, c := range cases {
+		if got := RelativeTime(c.t, now); got != c.want {
+			t.Fatalf("RelativeTime(%v) = %q; want %q", c.t, got, c.want)
+		}
+	}
+}
+
+func TestFuzzyMatch(t *testing.T) {
+	cases := []struct {
+		pattern, text string
+		want          []int
+		ok            bool
+	}{
+		{"", "main", nil, true},
+		{"mn", "main", []int{0, 3}, true},
+		{"FT", "feature/tree", []int{0, 3}, true},
+		{"xyz", "main", nil, false},
+		{"nm", "main", nil, false},
+	}
+	for _, c := range cases {
+		got, ok := FuzzyMatch(c.pattern, c.text)
+		if ok != c.ok || fmt.Sprint(got) != fmt.Sprint(c.want) {
+			t.Fatalf("FuzzyMatch(%q, %q) = %v, %v; want %v, %v", c.pattern, c.text, got, ok, c.want, c.ok)
+		}
+	}
+}
+
+func TestMoveCursorSkipsFilteredItems(t *testing.T) {
+	m := &model.Model{
+		CurrentView: model.BranchView,
+		Branches:    []string{"main", "feature/a", "develop", "feature/b"},
+		Filter:      "feat",
+		FilterView:  model.BranchView,
+	}
+	ValidateCursor(m)
+	if m.Cursor != 1 {
+		t.Fatalf("expected cursor on first match, got %d", m.Cursor)
+	}
+	MoveCursor(m, 1)
+	if m.Cursor != 3 {
+		t.Fatalf("expected cursor to skip hidden branch, got %d", m.Cursor)
+	}
+	MoveCursor(m, 1)
+	if m.Cursor != 3 {
+		t.Fatalf("expected cursor to stay on last match, got %d", m.Cursor)
+	}
+}
+
+func TestFileTree(t *testing.T) {
+	m := &model.Model{
+		CurrentView: model.FileView,
+		FileTree:    true,
+		Files: []git.FileItem{
+			{Name: "main.go", Status: "M"},
+			{Name: "internal/tui/app.go", Status: "M", Staged: true},
+			{Name: "internal/tui/views/file_view.go", Status: "M"},
+			{Name: "docs/", Status: "??"},
+		},
+	}
+
+	var got []string
+	for _, row := range FileTree(m) {
+		got = append(got, fmt.Sprintf("%d %s %d/%d", row.Depth, row.Label, row.Staged, row.Files))
+	}
+	want := []string{"0 internal/tui 1/2", "1 views 0/1", "2 file_view.go 0/0", "1 app.go 0/0", "0 docs/ 0/0", "0 main.go 0/0"}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("FileTree() = %q; want %q", got, want)
+	}
+
+	m.Cursor = 2
+	m.CollapsedDirs = map[string]bool{"internal/tui/views": true}
+	if row := TreeRowOf(m, FileTree(m)); row != 1 {
+		t.Fatalf("expected a file in a collapsed directory at its row, got %d", row)
+	}
+	MoveCursor(m, -1)
+	if m.TreeDir != "internal/tui" {
+		t.Fatalf("expected the cursor to move onto the parent directory, got %q", m.TreeDir)
+	}
+}
+
+func TestFindMatch(t *testing.T) {
+	lines := []string{"func main() {", "\treturn Foo()", "}", "// foo bar"}
+
+	// lower-case queries are case-insensitive and wrap around the end
+	re := CompileSearch("foo")
+	if got := FindMatch(lines, re, 2, 1); got != 3 {
+		t.Fatalf("expected forward match at 3, got %d", got)
+	}
+	if got := FindMatch(lines, re, 0, -1); got != 3 {
+		t.Fatalf("expected backward match to wrap to 3, got %d", got)
+	}
+
+	// an upper-case letter makes the search case-sensitive
+	if got := FindMatch(lines, CompileSearch("Foo"), 2, 1); got != 1 {
+		t.Fatalf("expected case-sensitive match at 1, got %d", got)
+	}
+
+	// invalid regexps are matched literally
+	if got := FindMatch(lines, CompileSearch("main("), 0, 1); got != 0 {
+		t.Fatalf("expected literal match at 0, got %d", got)
+	}
+	if got := FindMatch(lines, CompileSearch("^}$"), 0, 1); got != 2 {
+		t.Fatalf("expected regexp match at 2, got %d", got)
+	}
+	if got := FindMatch(lines, CompileSearch("missing"), 0, 1); got != -1 {
+		t.Fatalf("expected no match, got %d", got)
+	}
+}
+
+func TestMoveCursorInLogGraph(t *testing.T) {
+	m := &model.Model{
+		CurrentView: model.LogGraphView,
+		LogLines:    []string{"* a1 one", "* b2 two"},
+	}
+	MoveCursor(m, 1)
+	if m.Cursor != 1 {
+		t.Fatalf("expected cursor 1, got %d", m.Cursor)
+	}
+}
+
+func TestJumpToMatchSkipsFilteredLogLines(t *testing.T) {
+	m := &model.Model{
+		CurrentView: model.LogGraphView,
+		LogLines:    []string{"* a1 fix parser", "* b2 docs", "* c3 fix lexer"},
+		Filter:      "lexer",
+		FilterView:  model.LogGraphView,
+		Search:      "fix",
+		SearchView:  model.LogGraphView,
+	}
+	if !JumpToMatch(m, 0, 1) || m.Cursor != 2 {
+		t.Fatalf("expected the match hidden by the filter to be skipped, cursor at %d", m.Cursor)
+	}
+	if total, rank := SearchCount(m); total != 1 || rank != 1 {
+		t.Fatalf("expected match 1/1, got %d/%d", rank, total)
+	}
+}
+
+func TestWordDiff(t *testing.T) {
+	old, new := WordDiff("return a + b", "return a - c")
+	want := "return a [+] [b]"
+	if got := formatSpans(old); got != want {
+		t.Fatalf("old spans = %q; want %q", got, want)
+	}
+	want = "return a [-] [c]"
+	if got := formatSpans(new); got != want {
+		t.Fatalf("new spans = %q; want %q", got, want)
+	}
+}
+
+func formatSpans(spans []DiffSpan) string {
+	s := ""
+	for _, span := range spans {
+		if span.Changed {
+			s += "[" + span.Text + "]"
+		} else {
+			s += span.Text
+		}
+	}
+	return s
+}
+
+func TestPairChangedLines(t *testing.T) {
+	lines := []string{"@@ -1,3 +1,2 @@", "-a", "-b", "+c", " d", "+e"}
+	pairs := PairChangedLines(lines)
+	if len(pairs) != 2 || pairs[1] != 3 || pairs[3] != 1 {
+		t.Fatalf("unexpected pairs %v", pairs)
+	}
+}
+
+func TestPairChangedLinesInsideHunks(t *testing.T) {
+	// a removed "-- comment" line and an added "++ x" line look like file
+	// headers once their diff prefix is added
+	lines := []string{"diff --git a/q.sql b/q.sql", "--- a/q.sql", "+++ b/q.sql", "@@ -1 +1 @@", "--- old", "+++ new"}
+	pairs := PairChangedLines(lines)
+	if len(pairs) != 2 || pairs[4] != 5 || pairs[5] != 4 {
+		t.Fatalf("unexpected pairs %v", pairs)
+	}
+}
+
+func TestDiffLineNumber(t *testing.T) {
+	lines := []string{"diff --git a/f b/f", "@@ -3,3 +5,4 @@", " a", "-b", "+c", "+d", " e"}
+	cases := map[int]int{0: 5, 1: 5, 2: 5, 3: 6, 4: 6, 5: 7, 6: 8}
+	for index, want := range cases {
+		if got := DiffLineNumber(lines, index); got != want {
+			t.Errorf("DiffLineNumber(%d) = %d; want %d", index, got, want)
+		}
+	}
+}
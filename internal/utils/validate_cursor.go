@@ -13,11 +13,14 @@ func ValidateCursor(m *model.Model) {
 		} else if m.Cursor < 0 {
 			m.Cursor = 0
 		}
+		if m.TreeDir != "" && !m.HasFilesIn(m.TreeDir) {
+			m.TreeDir = ""
+		}
 	case model.BranchView:
-		if len(m.Branches) == 0 {
+		if m.BranchRowCount() == 0 {
 			m.Cursor = 0
-		} else if m.Cursor >= len(m.Branches) {
-			m.Cursor = len(m.Branches) - 1
+		} else if m.Cursor >= m.BranchRowCount() {
+			m.Cursor = m.BranchRowCount() - 1
 		} else if m.Cursor < 0 {
 			m.Cursor = 0
 		}
@@ -29,5 +32,33 @@ func ValidateCursor(m *model.Model) {
 		} else if m.Cursor < 0 {
 			m.Cursor = 0
 		}
+	case model.StashView:
+		if m.Cursor >= len(m.Stashes) {
+			m.Cursor = max(0, len(m.Stashes)-1)
+		} else if m.Cursor < 0 {
+			m.Cursor = 0
+		}
+	case model.LogGraphView:
+		if m.Cursor >= len(m.LogLines) {
+			m.Cursor = max(0, len(m.LogLines)-1)
+		} else if m.Cursor < 0 {
+			m.Cursor = 0
+		}
+	case model.RepositoryListView:
+		if m.SelectedRepoIndex >= len(m.Repositories) {
+			m.SelectedRepoIndex = max(0, len(m.Repositories)-1)
+		} else if m.SelectedRepoIndex < 0 {
+			m.SelectedRepoIndex = 0
+		}
+	}
+
+	// keep the cursor on an item that passes the filter
+	if IsFilterable(m.CurrentView) && m.FilterActive() && !IsVisible(m, *cursorOf(m)) {
+		if visible := VisibleIndices(m); len(visible) > 0 {
+			*cursorOf(m) = visible[0]
+		}
+	}
+	if m.CurrentView == model.FileView {
+		EnsureFileVisible(m)
 	}
 }
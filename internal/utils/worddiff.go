@@ -0,0 +1,148 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+)
+
+// maxWordDiffCells bounds the work WordDiff does on very long lines.
+const maxWordDiffCells = 250000
+
+// DiffSpan is a piece of a line in a word diff.
+type DiffSpan struct {
+	Text    string
+	Changed bool
+}
+
+// WordDiff compares a removed line with the line that replaced it, token by
+// token, and returns both split into spans of unchanged and changed text.
+func WordDiff(old, new string) (oldSpans, newSpans []DiffSpan) {
+	a, b := tokenize(old), tokenize(new)
+	if len(a)*len(b) > maxWordDiffCells {
+		return []DiffSpan{{Text: old, Changed: true}}, []DiffSpan{{Text: new, Changed: true}}
+	}
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			oldSpans = appendSpan(oldSpans, a[i], false)
+			newSpans = appendSpan(newSpans, b[j], false)
+			i++
+			j++
+		case j == len(b) || (i < len(a) && lcs[i+1][j] >= lcs[i][j+1]):
+			oldSpans = appendSpan(oldSpans, a[i], true)
+			i++
+		default:
+			newSpans = appendSpan(newSpans, b[j], true)
+			j++
+		}
+	}
+	return oldSpans, newSpans
+}
+
+// appendSpan adds text to spans, merging it into the last span when both are
+// changed or both are not.
+func appendSpan(spans []DiffSpan, text string, changed bool) []DiffSpan {
+	if n := len(spans); n > 0 && spans[n-1].Changed == changed {
+		spans[n-1].Text += text
+		return spans
+	}
+	return append(spans, DiffSpan{Text: text, Changed: changed})
+}
+
+// tokenize splits a line into words, runs of whitespace and single
+// punctuation characters.
+func tokenize(s string) []string {
+	var tokens []string
+	var current strings.Builder
+	kind := 0 // 1 for word characters, 2 for whitespace
+
+	flush := func() {
+		if current.Len() > 0 {
+			tokens = append(tokens, current.String())
+			current.Reset()
+		}
+	}
+
+	for _, r := range s {
+		k := 0
+		switch {
+		case r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r):
+			k = 1
+		case unicode.IsSpace(r):
+			k = 2
+		}
+		if k == 0 || k != kind {
+			flush()
+		}
+		kind = k
+		current.WriteRune(r)
+	}
+	flush()
+	return tokens
+}
+
+// PairChangedLines matches the removed and added lines of a unified diff
+// that replace each other: within a block of "-" lines directly followed by
+// "+" lines, the nth removed line pairs with the nth added one. The result
+// maps the index of each paired line to the index of its partner.
+func PairChangedLines(lines []string) map[int]int {
+	kinds := changeKinds(lines)
+	pairs := make(map[int]int)
+	for i := 0; i < len(lines); {
+		if kinds[i] != '-' {
+			i++
+			continue
+		}
+		start := i
+		for i < len(lines) && kinds[i] == '-' {
+			i++
+		}
+		added := i
+		for i < len(lines) && kinds[i] == '+' {
+			i++
+		}
+		for k := 0; start+k < added && added+k < i; k++ {
+			pairs[start+k] = added + k
+			pairs[added+k] = start + k
+		}
+	}
+	return pairs
+}
+
+// changeKinds returns '-' for each removed line and '+' for each added line
+// of a diff, 0 for the others. Only lines inside a hunk count, so the
+// "---"/"+++" file headers are told apart from content lines that happen to
+// start the same way.
+func changeKinds(lines []string) []byte {
+	kinds := make([]byte, len(lines))
+	inHunk := false
+	for i, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "diff "):
+			inHunk = false
+		case strings.HasPrefix(line, "@@"):
+			inHunk = true
+		case !inHunk:
+		case strings.HasPrefix(line, "-"), strings.HasPrefix(line, "+"):
+			kinds[i] = line[0]
+		}
+	}
+	return kinds
+}
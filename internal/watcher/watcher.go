@@ -0,0 +1,355 @@
+// Package watcher notices changes to a repository made outside froggit, such
+// as edits from an editor or git commands run in another terminal.
+//
+// It watches the worktree and the parts of .git that matter for the status
+// (index, HEAD and refs) with fsnotify, and falls back to polling git status
+// when file notifications are unavailable. In large repositories only the
+// root of the worktree is watched, as a watch per directory would run into
+// the system's limit on them.
+package watcher
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"froggit/internal/git"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+const (
+	// debounce is how long the repository must be quiet before a change is
+	// reported, so a save or a checkout touching many files reports once.
+	debounce = 300 * time.Millisecond
+	// maxDelay bounds how long a stream of events can postpone the report.
+	maxDelay = 2 * time.Second
+	// pollInterval is how often the status is compared when polling.
+	pollInterval = 2 * time.Second
+)
+
+// gitFiles are the files in .git whose changes affect what froggit shows.
+var gitFiles = map[string]bool{
+	"index":            true,
+	"HEAD":             true,
+	"packed-refs":      true,
+	"MERGE_HEAD":       true,
+	"REBASE_HEAD":      true,
+	"CHERRY_PICK_HEAD": true,
+	"ORIG_HEAD":        true,
+	"refs/stash":       true,
+}
+
+// Change tells which parts of a repository changed.
+type Change int
+
+const (
+	WorktreeChanged Change = 1 << iota // files in the worktree or the index
+	RefsChanged                        // HEAD, branches, tags or the operation in progress
+	StashChanged
+
+	AnyChange = WorktreeChanged | RefsChanged | StashChanged
+)
+
+// Watcher reports changes to a repository on its Changes channel.
+type Watcher struct {
+	root    string
+	gitDir  string
+	client  *git.GitClient
+	changes chan struct{}
+	done    chan struct{}
+	once    sync.Once
+
+	mu      sync.Mutex
+	changed Change // parts changed since TakeChanges was last called
+	err     error  // why directories went unwatched, since TakeError was last called
+
+	fs *fsnotify.Watcher // nil when polling
+}
+
+// New starts watching the repository at root. With poll set, or when file
+// notifications can't be set up, it polls git status instead. With large
+// set, only the root of the worktree is watched, so changes further down
+// are left to a refresh.
+func New(root string, poll, large bool) (*Watcher, error) {
+	client := git.NewGitClient(root)
+	gitDir, err := client.GitDir()
+	if err != nil {
+		return nil, err
+	}
+
+	w := &Watcher{
+		root:    root,
+		gitDir:  gitDir,
+		client:  client,
+		changes: make(chan struct{}, 1),
+		done:    make(chan struct{}),
+	}
+
+	if !poll {
+		fsw, err := w.startNotify(large)
+		if err == nil {
+			w.fs = fsw
+			go w.notifyLoop(large)
+			return w, nil
+		}
+		w.err = fmt.Errorf("failed to watch files, polling instead: %w", err)
+	}
+	go w.pollLoop()
+	return w, nil
+}
+
+// Changes delivers a value after the repository changed. Changes that happen
+// before the previous one was received are merged into it.
+func (w *Watcher) Changes() <-chan struct{} {
+	return w.changes
+}
+
+// TakeChanges returns the parts of the repository that changed since it was
+// last called.
+func (w *Watcher) TakeChanges() Change {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	changed := w.changed
+	w.changed = 0
+	return changed
+}
+
+// TakeError returns why files went unwatched since it was last called, such
+// as running out of inotify watches, or nil.
+func (w *Watcher) TakeError() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	err := w.err
+	w.err = nil
+	return err
+}
+
+// Polling reports whether the watcher fell back to polling.
+func (w *Watcher) Polling() bool {
+	return w.fs == nil
+}
+
+// Close stops watching.
+func (w *Watcher) Close() {
+	w.once.Do(func() {
+		close(w.done)
+		if w.fs != nil {
+			w.fs.Close()
+		}
+	})
+}
+
+func (w *Watcher) notify(change Change) {
+	w.mu.Lock()
+	w.changed |= change
+	w.mu.Unlock()
+	select {
+	case w.changes <- struct{}{}:
+	default:
+	}
+}
+
+// startNotify watches every directory of the worktree that isn't ignored,
+// or only its root when large, the .git directory and its refs.
+func (w *Watcher) startNotify(large bool) (*fsnotify.Watcher, error) {
+	fsw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	dirs := []string{"."}
+	if !large {
+		dirs, err = w.client.WorktreeDirs()
+		if err != nil {
+			fsw.Close()
+			return nil, err
+		}
+	}
+	for _, dir := range dirs {
+		if err := fsw.Add(filepath.Join(w.root, dir)); err != nil {
+			// usually the inotify watch limit; polling still works
+			fsw.Close()
+			return nil, err
+		}
+	}
+
+	if err := fsw.Add(w.gitDir); err != nil {
+		fsw.Close()
+		return nil, err
+	}
+	err = filepath.WalkDir(filepath.Join(w.gitDir, "refs"), func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.IsDir() {
+			return err
+		}
+		return fsw.Add(path)
+	})
+	if err != nil {
+		fsw.Close()
+		return nil, err
+	}
+	return fsw, nil
+}
+
+// notifyLoop collects file events and reports a change once they settle,
+// unless every event was about ignored files. New directories of the
+// worktree are watched too, unless it is large.
+func (w *Watcher) notifyLoop(large bool) {
+	var (
+		pending  []string // worktree paths that changed, relative to root
+		relevant Change   // changes in .git, which always count
+		timer    <-chan time.Time
+		first    time.Time
+	)
+
+	for {
+		select {
+		case <-w.done:
+			return
+
+		case event, ok := <-w.fs.Events:
+			if !ok {
+				return
+			}
+			if event.Op == fsnotify.Chmod {
+				continue
+			}
+			if w.inGitDir(event.Name) {
+				change := w.gitStateChange(event.Name)
+				if change == 0 {
+					continue
+				}
+				relevant |= change
+				if event.Op&fsnotify.Create != 0 {
+					w.watchNewDir(event.Name, false)
+				}
+			} else {
+				rel, err := filepath.Rel(w.root, event.Name)
+				if err != nil {
+					continue
+				}
+				pending = append(pending, rel)
+				if event.Op&fsnotify.Create != 0 && !large {
+					w.watchNewDir(event.Name, true)
+				}
+			}
+
+			now := time.Now()
+			if timer == nil {
+				first = now
+			}
+			wait := min(debounce, maxDelay-now.Sub(first))
+			timer = time.After(max(0, wait))
+
+		case <-w.fs.Errors:
+			// events may have been dropped; refreshing catches up
+			relevant = AnyChange
+
+		case <-timer:
+			change := relevant
+			if w.anyNotIgnored(pending) {
+				change |= WorktreeChanged
+			}
+			if change != 0 {
+				w.notify(change)
+			}
+			pending, relevant, timer = nil, 0, nil
+		}
+	}
+}
+
+func (w *Watcher) inGitDir(path string) bool {
+	return path == w.gitDir || strings.HasPrefix(path, w.gitDir+string(filepath.Separator))
+}
+
+// gitStateChange tells what a change to a path in .git affects: the index,
+// HEAD-like files and refs matter, their lock files and the rest of .git
+// don't, which is reported as 0.
+func (w *Watcher) gitStateChange(path string) Change {
+	rel, err := filepath.Rel(w.gitDir, path)
+	if err != nil || strings.HasSuffix(rel, ".lock") {
+		return 0
+	}
+	rel = filepath.ToSlash(rel)
+	switch {
+	case rel == "index":
+		return WorktreeChanged
+	case rel == "refs/stash":
+		return StashChanged
+	case gitFiles[rel] || strings.HasPrefix(rel, "refs/"):
+		return RefsChanged
+	}
+	return 0
+}
+
+// anyNotIgnored reports whether any of the worktree paths isn't ignored.
+func (w *Watcher) anyNotIgnored(paths []string) bool {
+	if len(paths) == 0 {
+		return false
+	}
+	ignored, err := w.client.CheckIgnored(paths)
+	if err != nil {
+		return true
+	}
+	for _, path := range paths {
+		if !ignored[path] {
+			return true
+		}
+	}
+	return false
+}
+
+// watchNewDir starts watching a directory created after the watcher started,
+// with its subdirectories. Ignored directories in the worktree are skipped.
+// When a watch can't be added, the error is kept for TakeError and the
+// change reported, so it is shown.
+func (w *Watcher) watchNewDir(path string, worktree bool) {
+	filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || !d.IsDir() {
+			return nil
+		}
+		if worktree {
+			rel, err := filepath.Rel(w.root, p)
+			if err != nil {
+				return filepath.SkipDir
+			}
+			if ignored, err := w.client.CheckIgnored([]string{rel + "/"}); err == nil && ignored[rel+"/"] {
+				return filepath.SkipDir
+			}
+		}
+		if err := w.fs.Add(p); err != nil {
+			w.mu.Lock()
+			w.err = fmt.Errorf("failed to watch %s: %w", p, err)
+			w.mu.Unlock()
+			w.notify(WorktreeChanged)
+			return filepath.SkipAll
+		}
+		return nil
+	})
+}
+
+// pollLoop compares the status at a fixed interval and reports when it
+// changed.
+func (w *Watcher) pollLoop() {
+	last, _ := w.client.StatusFingerprint()
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.done:
+			return
+		case <-ticker.C:
+			current, err := w.client.StatusFingerprint()
+			if err != nil || current == last {
+				continue
+			}
+			last = current
+			// the status holds the files and the branch, not the stashes
+			w.notify(WorktreeChanged | RefsChanged)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package watcher
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWatcherReportsChangesButNotIgnoredFiles(t *testing.T) {
+	root := t.TempDir()
+	run := func(args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = root
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	run("init", "-q")
+	os.WriteFile(filepath.Join(root, ".gitignore"), []byte("*.log\n"), 0o644)
+
+	w, err := New(root, false, false)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer w.Close()
+	if w.Polling() {
+		t.Skip("file notifications unavailable")
+	}
+
+	os.WriteFile(filepath.Join(root, "debug.log"), []byte("x"), 0o644)
+	select {
+	case <-w.Changes():
+		t.Fatalf("expected writes to ignored files to be skipped")
+	case <-time.After(debounce + time.Second):
+	}
+
+	os.WriteFile(filepath.Join(root, "main.go"), []byte("package main\n"), 0o644)
+	select {
+	case <-w.Changes():
+	case <-time.After(debounce + 3*time.Second):
+		t.Fatalf("expected a change after writing main.go")
+	}
+	if change := w.TakeChanges(); change != WorktreeChanged {
+		t.Fatalf("expected a worktree change, got %b", change)
+	}
+}
+
+func TestLargeWatcherWatchesOnlyTheRoot(t *testing.T) {
+	root := t.TempDir()
+	if out, err := exec.Command("git", "-C", root, "init", "-q").CombinedOutput(); err != nil {
+		t.Fatalf("git init: %v\n%s", err, out)
+	}
+	os.MkdirAll(filepath.Join(root, "sub"), 0o755)
+	os.WriteFile(filepath.Join(root, "sub", "a.go"), []byte("package sub\n"), 0o644)
+
+	w, err := New(root, false, true)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer w.Close()
+	if w.Polling() {
+		t.Skip("file notifications unavailable")
+	}
+	if err := w.TakeError(); err != nil {
+		t.Fatalf("TakeError() = %v", err)
+	}
+
+	os.WriteFile(filepath.Join(root, "sub", "a.go"), []byte("package sub // edited\n"), 0o644)
+	select {
+	case <-w.Changes():
+		t.Fatalf("expected subdirectories of a large worktree to go unwatched")
+	case <-time.After(debounce + time.Second):
+	}
+
+	os.WriteFile(filepath.Join(root, "main.go"), []byte("package main\n"), 0o644)
+	select {
+	case <-w.Changes():
+	case <-time.After(debounce + 3*time.Second):
+		t.Fatalf("expected a change after writing main.go")
+	}
+}
+
+func TestGitStateChange(t *testing.T) {
+	w := &Watcher{gitDir: filepath.Join("repo", ".git")}
+	tests := map[string]Change{
+		"index":             WorktreeChanged,
+		"HEAD":              RefsChanged,
+		"refs/heads/main":   RefsChanged,
+		"refs/stash":        StashChanged,
+		"index.lock":        0,
+		"objects/ab/cdef01": 0,
+	}
+	for rel, want := range tests {
+		if got := w.gitStateChange(filepath.Join(w.gitDir, rel)); got != want {
+			t.Errorf("gitStateChange(%q) = %b; want %b", rel, got, want)
+		}
+	}
+}
@@ -6,12 +6,14 @@ import (
 	"log"
 	"os"
 
+	"froggit/internal/askpass"
 	"froggit/internal/config"
 	"froggit/internal/git"
 	tui "froggit/internal/tui"
 	"froggit/internal/tui/model"
 	"froggit/internal/tui/update"
 	"froggit/internal/updater"
+	"froggit/internal/watcher"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -84,6 +86,12 @@ func (a App) View() string {
 }
 
 func main() {
+	// git and ssh run this binary as GIT_ASKPASS/SSH_ASKPASS; relay the
+	// prompt to the running TUI instead of starting a new one.
+	if askpass.IsHelper() {
+		os.Exit(askpass.RunHelper(os.Args[1:]))
+	}
+
 	versionFlag := flag.Bool("version", false, "Print version information")
 	helpFlag := flag.Bool("help", false, "Print help information")
 	commandsFlag := flag.Bool("commands", false, "List supported Git commands")
@@ -94,8 +102,8 @@ func main() {
 	cfg, err := config.LoadConfig("froggit.yml")
 	if err != nil {
 		cfg = config.Config{
-			Ui:  config.UiConfig{Branding: true, Position: "center"},
-			Git: config.GitConfig{DefaultBranch: "main", AutoFetch: true},
+			Ui:  config.UiConfig{Branding: true, Position: "center", Layout: "single"},
+			Git: config.GitConfig{DefaultBranch: "main", AutoFetch: true, Watch: "auto", LargeRepo: "auto"},
 		}
 	}
 
@@ -131,12 +139,38 @@ func main() {
 		}
 	}
 
+	m := model.InitialModel(git.LoadStatusOptions(cfg.Git.LargeRepo, cfg.Git.FSMonitor))
+	m.DiffRenderer = git.LoadDiffRenderer(cfg.Git.DiffPager)
+	if cfg.Ui.Layout == "dashboard" {
+		m.EnableDashboard()
+	}
+	if cfg.Git.Watch != "off" {
+		if w, err := watcher.New(git.RepoRoot(), cfg.Git.Watch == "poll", m.StatusOptions.Large); err == nil {
+			defer w.Close()
+			m.Watcher = w
+			if err := w.TakeError(); err != nil {
+				m.Message = fmt.Sprintf("⚠ %s", err)
+				m.MessageType = "warning"
+			}
+		}
+	}
+	if srv, err := askpass.Listen(); err == nil {
+		defer srv.Close()
+		if exe, err := os.Executable(); err == nil {
+			git.SetCommandEnv(srv.Env(exe))
+			m.Askpass = srv
+		}
+	}
+	if m.Askpass == nil {
+		git.SetCommandEnv(askpass.FallbackEnv())
+	}
+
 	app := tui.App{
-		M: model.InitialModel(),
+		M: m,
 		C: cfg,
 	}
 
-	p := tea.NewProgram(app, tea.WithAltScreen())
+	p := tea.NewProgram(app, tea.WithAltScreen(), tea.WithMouseCellMotion())
 	if _, err := p.Run(); err != nil {
 		log.Fatal(err)
 	}